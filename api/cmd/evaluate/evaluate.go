@@ -0,0 +1,209 @@
+package evaluate
+
+import (
+	"context"
+	"fmt"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/config"
+	"github.com/koind/banner-rotation/api/internal/db"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/koind/banner-rotation/api/internal/evaluation"
+	"github.com/koind/banner-rotation/api/internal/storage/postgres"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// Flags of the evaluate command
+var (
+	slotID     int
+	groupID    int
+	strategies []string
+	logPath    string
+	reward     string
+	seed       int64
+)
+
+// Declaring commands to evaluate the strategies on the historical statistics
+var EvaluateCmd = &cobra.Command{
+	Use:   "evaluate",
+	Short: "Evaluate strategies on the historical statistics",
+	Long: "Replays the statistics of the slot and group, from the database or from an exported event log, " +
+		"through the strategies and reports the estimated CTR, regret and traffic share per banner",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.Init(config.Path)
+
+		statisticsList, slot := load(cfg)
+		if reward != "" {
+			slot.Reward = reward
+		}
+
+		events := evaluation.Events(statisticsList, slot.Reward, cfg.Strategies.MaxConversionValue)
+		if len(events) == 0 {
+			log.Fatal("There are no impressions to replay")
+		}
+
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+
+		registry := algorithm.NewDefaultRegistry(algorithm.NewLockedSource(seed), cfg.Strategies)
+		if len(strategies) == 0 {
+			strategies = registry.Names()
+		}
+
+		for _, name := range strategies {
+			report, err := replay(registry, name, algorithm.Parameters(slot.Parameters), events)
+			if err != nil {
+				log.Fatalf("failing to replay the strategy %s %v", name, err)
+			}
+
+			printReport(os.Stdout, name, report)
+		}
+	},
+}
+
+// Returns the statistics to replay and the settings of the slot
+func load(cfg config.Options) ([]*repository.Statistics, repository.Slot) {
+	slot := repository.Slot{ID: slotID}
+
+	if logPath != "" {
+		file, err := os.Open(logPath)
+		if err != nil {
+			log.Fatalf("failing to open the event log %v", err)
+		}
+		defer file.Close()
+
+		statisticsList, err := evaluation.ReadEvents(file)
+		if err != nil {
+			log.Fatalf("failing to read the event log %v", err)
+		}
+
+		filtered := make([]*repository.Statistics, 0, len(statisticsList))
+		for _, statistics := range statisticsList {
+			if (slotID == 0 || statistics.SlotID == slotID) && (groupID == 0 || statistics.GroupID == groupID) {
+				filtered = append(filtered, statistics)
+			}
+		}
+
+		return filtered, slot
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logger.Sync()
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Duration(cfg.Postgres.PingTimeout)*time.Millisecond,
+	)
+	defer cancel()
+
+	pg, err := db.IntPostgres(ctx, config.Postgres(cfg.Postgres))
+	if err != nil {
+		log.Fatalf("failing to connect to the database %v", err)
+	}
+	defer pg.Close()
+
+	settings, err := postgres.NewSlotRepository(pg, *logger).FindOneByID(context.Background(), slotID)
+	if err != nil {
+		log.Fatalf("failing to get the slot settings %v", err)
+	}
+
+	if settings != nil {
+		slot = *settings
+	}
+
+	statisticsList, err := postgres.NewStatisticsRepository(pg, *logger).
+		FindAllBySlotIDAndGroupID(context.Background(), slotID, groupID)
+	if err != nil {
+		log.Fatalf("failing to get the statistics %v", err)
+	}
+
+	return statisticsList, slot
+}
+
+// Replays the events through the strategy, the contextual algorithms are updated with every accepted impression,
+// the others are created for every impression from the discounted counters as the service does
+func replay(
+	registry *algorithm.Registry,
+	name string,
+	params algorithm.Parameters,
+	events []evaluation.Event,
+) (*evaluation.Report, error) {
+	if registry.IsContextual(name) {
+		// the replay resets the number of arms
+		a, err := registry.NewContextual(name, 1, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return evaluation.Replay(a, events)
+	}
+
+	err := registry.Validate(name, params)
+	if err != nil {
+		return nil, err
+	}
+
+	factory := func(counts []float64, rewards []float64) (algorithm.Algorithm, error) {
+		return registry.New(name, counts, rewards, params)
+	}
+
+	return evaluation.ReplayDiscounted(factory, registry.Discount(name), registry.IsWeighted(name), events)
+}
+
+// Prints the report of the strategy
+func printReport(w io.Writer, name string, report *evaluation.Report) {
+	fmt.Fprintf(
+		w,
+		"strategy: %s\nevents: %d, accepted: %d, ctr: %.4f, regret: %.2f\n",
+		name,
+		report.Events,
+		report.Accepted,
+		report.CTR,
+		report.Regret,
+	)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "banner\tlogged views\tlogged ctr\tviews\tctr\ttraffic share")
+
+	for _, banner := range report.Banners {
+		fmt.Fprintf(
+			tw,
+			"%d\t%d\t%.4f\t%d\t%.4f\t%.2f%%\n",
+			banner.BannerID,
+			banner.LoggedViews,
+			banner.LoggedCTR(),
+			banner.Views,
+			banner.CTR(),
+			banner.TrafficShare*100,
+		)
+	}
+
+	tw.Flush()
+	fmt.Fprintln(w)
+}
+
+// When initializing parse the path to the configuration and the replay options
+func init() {
+	EvaluateCmd.Flags().StringVarP(
+		&config.Path,
+		"config",
+		"c",
+		"config/development/config.toml",
+		"Path to toml configuration file",
+	)
+	EvaluateCmd.Flags().IntVarP(&slotID, "slot", "s", 0, "Slot to replay, all the slots of the event log if zero")
+	EvaluateCmd.Flags().IntVarP(&groupID, "group", "g", 0, "Group to replay, all the groups of the event log if zero")
+	EvaluateCmd.Flags().StringSliceVar(&strategies, "strategy", nil, "Strategies to evaluate, all if empty")
+	EvaluateCmd.Flags().StringVarP(&logPath, "log", "l", "", "Exported event log to replay instead of the database")
+	EvaluateCmd.Flags().StringVar(&reward, "reward", "", "Reward to replay: clicks or conversions, the slot reward if empty")
+	EvaluateCmd.Flags().Int64Var(&seed, "seed", 0, "Seed of the random source, the current time if zero")
+}
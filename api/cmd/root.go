@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"github.com/koind/banner-rotation/api/cmd/evaluate"
 	"github.com/koind/banner-rotation/api/cmd/server"
+	"github.com/koind/banner-rotation/api/cmd/simulate"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -12,9 +14,11 @@ var rootCmd = &cobra.Command{
 	Short: "Microservice banner-rotation",
 }
 
-// Adds http and grpc server commands during initialization
+// Adds http and grpc server commands, the evaluate and simulate commands during initialization
 func init() {
 	rootCmd.AddCommand(server.RunServerCmd)
+	rootCmd.AddCommand(evaluate.EvaluateCmd)
+	rootCmd.AddCommand(simulate.SimulateCmd)
 }
 
 // Runs the application
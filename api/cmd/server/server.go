@@ -2,10 +2,14 @@ package server
 
 import (
 	"context"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
 	"github.com/koind/banner-rotation/api/internal/config"
 	"github.com/koind/banner-rotation/api/internal/db"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
 	"github.com/koind/banner-rotation/api/internal/domain/service"
 	"github.com/koind/banner-rotation/api/internal/rabbit"
+	"github.com/koind/banner-rotation/api/internal/storage/cache"
+	"github.com/koind/banner-rotation/api/internal/storage/memory"
 	"github.com/koind/banner-rotation/api/internal/storage/postgres"
 	"github.com/koind/banner-rotation/api/internal/transport/grpc"
 	"github.com/koind/banner-rotation/api/internal/transport/http"
@@ -14,6 +18,8 @@ import (
 	"go.uber.org/zap"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 )
 
@@ -23,27 +29,48 @@ var RunServerCmd = &cobra.Command{
 	Short: "Run server",
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := config.Init(config.Path)
-		rotationService, publisher, logger := Init(cfg)
+		rotationService, publisher, logger, c := Init(cfg)
 		serverType := os.Getenv("SERVER_TYPE")
 
+		if c != nil {
+			go c.Run()
+		}
+
+		go expire(rotationService, time.Duration(cfg.Frequency.ExpireInterval)*time.Minute, logger)
+		go prune(rotationService, time.Duration(cfg.Counters.PruneInterval)*time.Minute, logger)
+
+		var s server
+
 		switch serverType {
 		case "HTTP":
 			httpRotationService := http.NewHTTPRotationService(*rotationService, publisher, logger)
-			hs := http.NewHTTPServer(httpRotationService, cfg.HTTPServer.GetDomain())
-
-			logger.Error("Error starting http server", zap.Error(hs.Start()))
+			s = http.NewHTTPServer(httpRotationService, cfg.HTTPServer.GetDomain())
 		case "GRPC":
-			gs := grpc.NewGRPCServer(cfg.GRPCServer.GetDomain(), *rotationService, publisher, logger)
-
-			logger.Error("Error starting grpc server", zap.Error(gs.Start()))
+			s = grpc.NewGRPCServer(cfg.GRPCServer.GetDomain(), *rotationService, publisher, logger)
 		default:
 			log.Fatal("Specified the wrong server type")
 		}
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- s.Start()
+		}()
+
+		select {
+		case err := <-errs:
+			logger.Error("Error starting server", zap.Error(err))
+		case <-signals:
+		}
+
+		shutdown(s, c, logger)
 	},
 }
 
 // Returns the initialized objects needed to start the server
-func Init(cfg config.Options) (*service.RotationService, *rabbit.Publisher, *zap.Logger) {
+func Init(cfg config.Options) (*service.RotationService, *rabbit.Publisher, *zap.Logger, *cache.Cache) {
 	logger, err := zap.NewProduction()
 	if err != nil {
 		log.Fatal(err)
@@ -67,17 +94,149 @@ func Init(cfg config.Options) (*service.RotationService, *rabbit.Publisher, *zap
 	}
 	defer conn.Close()
 
-	rotationRepository := postgres.NewRotationRepository(pg, *logger)
-	statisticsRepository := postgres.NewStatisticsRepository(pg, *logger)
-	statisticsService := service.StatisticsService{StatisticsRepository: statisticsRepository}
+	var rotationRepository repository.RotationRepositoryInterface = postgres.NewRotationRepository(pg, *logger)
+	var statisticsRepository repository.StatisticsRepositoryInterface = postgres.NewStatisticsRepository(pg, *logger)
+	var countersRepository repository.CountersRepositoryInterface = postgres.NewCountersRepository(pg, *logger)
+	var slotRepository repository.SlotRepositoryInterface = postgres.NewSlotRepository(pg, *logger)
+
+	var impressionsRepository repository.ImpressionsRepositoryInterface
+	switch cfg.Frequency.GetStore(cfg.Cache.Enabled) {
+	case config.ImpressionsStorePostgres:
+		impressionsRepository = postgres.NewImpressionsRepository(pg, *logger)
+	case config.ImpressionsStoreMemory:
+		impressionsRepository = memory.NewImpressionsRepository()
+	default:
+		log.Fatal("Specified the wrong store of the impressions")
+	}
+
+	transactor := postgres.NewTransactor(pg, *logger)
+
+	var c *cache.Cache
+	if cfg.Cache.Enabled {
+		c = cache.New(
+			cache.Options{
+				FlushInterval:   time.Duration(cfg.Cache.FlushInterval) * time.Millisecond,
+				FlushSize:       cfg.Cache.FlushSize,
+				RefreshInterval: time.Duration(cfg.Cache.RefreshInterval) * time.Millisecond,
+			},
+			statisticsRepository,
+			countersRepository,
+			rotationRepository,
+			slotRepository,
+			transactor,
+			*logger,
+		)
+
+		rotationRepository = c.Rotations
+		statisticsRepository = c.Statistics
+		countersRepository = c.Counters
+		slotRepository = c.Slots
+	}
+
+	statisticsService := service.StatisticsService{
+		StatisticsRepository: statisticsRepository,
+		CountersRepository:   countersRepository,
+	}
+
+	// the cache writes the statistics and counters to the database in one transaction at the flush
+	if !cfg.Cache.Enabled {
+		statisticsService.Transactor = transactor
+	}
 	publisher := rabbit.NewPublisher(conn, cfg.RabbitMQ.ExchangeName, cfg.RabbitMQ.QueueName)
+	seed := cfg.Strategies.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	strategies := algorithm.NewDefaultRegistry(algorithm.NewLockedSource(seed), cfg.Strategies)
+
+	_, err = algorithm.NewExamination(cfg.Strategies.ClickModel, cfg.Strategies.PositionBias, 0)
+	if err != nil {
+		log.Fatalf("invalid click model %v", err)
+	}
+
+	location, err := repository.LoadLocation(cfg.Dayparting.Timezone)
+	if err != nil {
+		log.Fatalf("invalid dayparting timezone %v", err)
+	}
+
 	rotationService := service.RotationService{
-		StatisticsService:    &statisticsService,
-		RotationRepository:   rotationRepository,
-		StatisticsRepository: statisticsRepository,
+		StatisticsService:     &statisticsService,
+		RotationRepository:    rotationRepository,
+		StatisticsRepository:  statisticsRepository,
+		CountersRepository:    countersRepository,
+		SlotRepository:        slotRepository,
+		Strategies:            strategies,
+		MaxConversionValue:    cfg.Strategies.MaxConversionValue,
+		ClickModel:            cfg.Strategies.ClickModel,
+		PositionBias:          cfg.Strategies.PositionBias,
+		Location:              location,
+		ShareWindow:           time.Duration(cfg.Strategies.ShareWindow) * time.Hour,
+		ImpressionsRepository: impressionsRepository,
+		FrequencyCap:          cfg.Frequency.Cap,
+		FrequencyWindow:       time.Duration(cfg.Frequency.Window) * time.Hour,
+	}
+
+	return &rotationService, publisher, logger, c
+}
+
+// HTTP or GRPC server of the rotation service
+type server interface {
+	Start() error
+	Shutdown(ctx context.Context) error
+}
+
+// Stops the server gracefully, then flushes the cache and exits
+func shutdown(s server, c *cache.Cache, logger *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	code := 0
+
+	if err := s.Shutdown(ctx); err != nil {
+		logger.Error("Error when stopping the server", zap.Error(err))
+		code = 1
+	}
+
+	if c != nil {
+		if err := c.Close(ctx); err != nil {
+			logger.Error("Error when flushing the cache", zap.Error(err))
+			code = 1
+		}
 	}
 
-	return &rotationService, publisher, logger
+	cancel()
+	os.Exit(code)
+}
+
+// Removes the expired impressions of the visitors at the interval
+func expire(rotationService *service.RotationService, interval time.Duration, logger *zap.Logger) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := rotationService.ExpireImpressions(context.Background()); err != nil {
+			logger.Error("Error when removing the expired impressions", zap.Error(err))
+		}
+	}
+}
+
+// Removes the hourly counters the strategies no longer weigh at the interval
+func prune(rotationService *service.RotationService, interval time.Duration, logger *zap.Logger) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := rotationService.RemoveExpiredCounters(context.Background()); err != nil {
+			logger.Error("Error when removing the expired counters", zap.Error(err))
+		}
+	}
 }
 
 // When initializing parse the path to the configuration
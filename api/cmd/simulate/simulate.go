@@ -0,0 +1,124 @@
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/config"
+	"github.com/koind/banner-rotation/api/internal/simulation"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+// Flags of the simulate command
+var (
+	simulationPath string
+	strategies     []string
+)
+
+// Declaring commands to simulate the rotation with synthetic banners
+var SimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate the rotation with synthetic banners",
+	Long: "Drives the rotation service with the in-memory repositories through synthetic traffic " +
+		"and reports the convergence curve, cumulative regret and share of traffic to the best banner",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.Init(config.Path)
+
+		options, err := simulation.DecodeFile(simulationPath)
+		if err != nil {
+			log.Fatalf("failing to load the simulation %v", err)
+		}
+
+		if len(strategies) == 0 {
+			strategies = []string{options.Strategy}
+		}
+
+		for _, name := range strategies {
+			options.Strategy = name
+
+			seed := cfg.Strategies.Seed
+			if seed == 0 {
+				seed = options.Seed
+			}
+
+			registry := algorithm.NewDefaultRegistry(algorithm.NewLockedSource(seed), cfg.Strategies)
+
+			result, err := simulation.Run(context.Background(), options, registry)
+			if err != nil {
+				log.Fatalf("failing to simulate the strategy %s %v", name, err)
+			}
+
+			printResult(os.Stdout, name, result)
+		}
+	},
+}
+
+// Prints the result of the simulation of the strategy
+func printResult(w io.Writer, name string, result *simulation.Result) {
+	fmt.Fprintf(
+		w,
+		"strategy: %s\nviews: %d, clicks: %d, regret: %.2f, best banner share: %.2f%%\n",
+		name,
+		result.Views,
+		result.Clicks,
+		result.Regret,
+		result.BestShare*100,
+	)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "views\tctr\tbest banner share\tregret")
+
+	for _, checkpoint := range result.Curve {
+		fmt.Fprintf(
+			tw,
+			"%d\t%.4f\t%.2f%%\t%.2f\n",
+			checkpoint.Views,
+			checkpoint.CTR,
+			checkpoint.BestShare*100,
+			checkpoint.Regret,
+		)
+	}
+
+	tw.Flush()
+
+	bannerIDs := make([]int, 0, len(result.TrafficShare))
+	for bannerID := range result.TrafficShare {
+		bannerIDs = append(bannerIDs, bannerID)
+	}
+
+	sort.Ints(bannerIDs)
+
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "banner\ttraffic share")
+
+	for _, bannerID := range bannerIDs {
+		fmt.Fprintf(tw, "%d\t%.2f%%\n", bannerID, result.TrafficShare[bannerID]*100)
+	}
+
+	tw.Flush()
+	fmt.Fprintln(w)
+}
+
+// When initializing parse the path to the configuration and the simulation
+func init() {
+	SimulateCmd.Flags().StringVarP(
+		&config.Path,
+		"config",
+		"c",
+		"config/development/config.toml",
+		"Path to toml configuration file",
+	)
+	SimulateCmd.Flags().StringVarP(
+		&simulationPath,
+		"simulation",
+		"f",
+		"config/development/simulation.toml",
+		"Path to toml file with the banners, groups and traffic of the simulation",
+	)
+	SimulateCmd.Flags().StringSliceVar(&strategies, "strategy", nil, "Strategies to simulate, the strategy of the simulation if empty")
+}
@@ -0,0 +1,81 @@
+package algorithm
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// No click model, the views at every position are counted equally
+	ClickModelNone = ""
+
+	// Position-based click model, the banner at the position is examined with the probability of the position bias
+	ClickModelPosition = "position"
+
+	// Cascade click model, the user examines the banners from the top and stops at the first click
+	ClickModelCascade = "cascade"
+)
+
+var (
+	ErrUnknownClickModel   = errors.New("unknown click model")
+	ErrInvalidPositionBias = errors.New("position bias must be greater than zero and not greater than one")
+)
+
+// Examination returns the probability that the banner shown at the position is examined,
+// the clicks of a banner divided by its views weighed by the examination are its debiased reward.
+// Positions start from one, zero is the unknown position and is examined as the first one
+type Examination func(position int) float64
+
+// PositionBased returns the examination of the position-based click model,
+// the positions after the last bias are examined with the last bias
+func PositionBased(bias []float64) Examination {
+	return func(position int) float64 {
+		if len(bias) == 0 {
+			return 1
+		}
+
+		i := position - 1
+		if i < 0 {
+			i = 0
+		}
+		if i >= len(bias) {
+			i = len(bias) - 1
+		}
+
+		return bias[i]
+	}
+}
+
+// Cascade returns the examination of the cascade click model, the banner is examined
+// when none of the banners above it was clicked, ctr is the probability of the click on a banner
+func Cascade(ctr float64) Examination {
+	ctr = math.Max(0, math.Min(ctr, 1))
+
+	return func(position int) float64 {
+		if position <= 1 {
+			return 1
+		}
+
+		return math.Pow(1-ctr, float64(position-1))
+	}
+}
+
+// NewExamination returns the examination of the click model, nil when the views are not debiased
+func NewExamination(model string, bias []float64, ctr float64) (Examination, error) {
+	switch model {
+	case ClickModelNone:
+		return nil, nil
+	case ClickModelPosition:
+		for _, b := range bias {
+			if b <= 0 || b > 1 {
+				return nil, ErrInvalidPositionBias
+			}
+		}
+
+		return PositionBased(bias), nil
+	case ClickModelCascade:
+		return Cascade(ctr), nil
+	}
+
+	return nil, ErrUnknownClickModel
+}
@@ -0,0 +1,50 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPositionBased(t *testing.T) {
+	examination := PositionBased([]float64{1, 0.6, 0.3})
+
+	assert.Equal(t, 1.0, examination(0), "unknown position should be examined as the first one")
+	assert.Equal(t, 1.0, examination(1))
+	assert.Equal(t, 0.6, examination(2))
+	assert.Equal(t, 0.3, examination(3))
+	assert.Equal(t, 0.3, examination(10), "positions after the last bias should use the last bias")
+
+	assert.Equal(t, 1.0, PositionBased(nil)(5), "empty bias should count all the views")
+}
+
+func TestCascade(t *testing.T) {
+	examination := Cascade(0.2)
+
+	assert.Equal(t, 1.0, examination(0))
+	assert.Equal(t, 1.0, examination(1))
+	assert.InDelta(t, 0.8, examination(2), 1e-9)
+	assert.InDelta(t, 0.64, examination(3), 1e-9)
+
+	assert.Equal(t, 1.0, Cascade(-1)(3), "negative ctr should be clamped")
+	assert.Equal(t, 0.0, Cascade(2)(2), "ctr above one should be clamped")
+}
+
+func TestNewExamination(t *testing.T) {
+	examination, err := NewExamination(ClickModelNone, nil, 0)
+	assert.Nil(t, err)
+	assert.Nil(t, examination)
+
+	examination, err = NewExamination(ClickModelPosition, []float64{1, 0.5}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, examination(2))
+
+	examination, err = NewExamination(ClickModelCascade, nil, 0.5)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.25, examination(3))
+
+	_, err = NewExamination(ClickModelPosition, []float64{1, 0}, 0)
+	assert.Equal(t, ErrInvalidPositionBias, err)
+
+	_, err = NewExamination("unknown", nil, 0)
+	assert.Equal(t, ErrUnknownClickModel, err)
+}
@@ -0,0 +1,68 @@
+package algorithm
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// Weight below which the observation is forgotten by the discount
+	ForgottenWeight = 0.001
+
+	// Longest horizon the discount is searched for
+	maxHorizon = 10 * 365 * 24 * time.Hour
+)
+
+// Discount returns the weight of an observation of the given age,
+// the non-stationary strategies use it to forget old observations
+type Discount func(age time.Duration) float64
+
+// SlidingWindow returns the discount that counts only the observations within the window,
+// a window that is not positive keeps all the observations
+func SlidingWindow(window time.Duration) Discount {
+	return func(age time.Duration) float64 {
+		if window > 0 && age > window {
+			return 0
+		}
+
+		return 1
+	}
+}
+
+// HalfLife returns the discount that halves the weight of an observation every half-life,
+// a half-life that is not positive keeps all the observations
+func HalfLife(halfLife time.Duration) Discount {
+	return func(age time.Duration) float64 {
+		if halfLife <= 0 || age <= 0 {
+			return 1
+		}
+
+		return math.Pow(0.5, float64(age)/float64(halfLife))
+	}
+}
+
+// Horizon returns the age after which the discount weighs the observations below the forgotten weight
+// to a minute, reports false when the discount keeps the observations for longer than ten years
+func (d Discount) Horizon() (time.Duration, bool) {
+	high := time.Hour
+	for d(high) >= ForgottenWeight {
+		if high >= maxHorizon {
+			return 0, false
+		}
+
+		high *= 2
+	}
+
+	low := time.Duration(0)
+	for high-low > time.Minute {
+		middle := low + (high-low)/2
+
+		if d(middle) >= ForgottenWeight {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+
+	return high, true
+}
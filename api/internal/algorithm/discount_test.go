@@ -0,0 +1,42 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	discount := SlidingWindow(time.Hour)
+
+	assert.Equal(t, 1.0, discount(0))
+	assert.Equal(t, 1.0, discount(time.Hour))
+	assert.Equal(t, 0.0, discount(time.Hour+time.Second))
+
+	assert.Equal(t, 1.0, SlidingWindow(0)(1000*time.Hour), "empty window should keep all the observations")
+}
+
+func TestHalfLife(t *testing.T) {
+	discount := HalfLife(time.Hour)
+
+	assert.Equal(t, 1.0, discount(0))
+	assert.Equal(t, 1.0, discount(-time.Minute), "observations from the future should not be amplified")
+	assert.InDelta(t, 0.5, discount(time.Hour), 1e-9)
+	assert.InDelta(t, 0.25, discount(2*time.Hour), 1e-9)
+
+	assert.Equal(t, 1.0, HalfLife(0)(1000*time.Hour), "empty half-life should keep all the observations")
+}
+
+func TestDiscount_Horizon(t *testing.T) {
+	horizon, has := SlidingWindow(time.Hour).Horizon()
+	assert.True(t, has)
+	assert.InDelta(t, time.Hour.Minutes(), horizon.Minutes(), 1)
+
+	horizon, has = HalfLife(time.Hour).Horizon()
+	assert.True(t, has)
+	assert.InDelta(t, math.Log2(1/ForgottenWeight)*60, horizon.Minutes(), 1)
+
+	_, has = SlidingWindow(0).Horizon()
+	assert.False(t, has, "empty window should never forget the observations")
+}
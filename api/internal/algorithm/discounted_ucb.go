@@ -0,0 +1,76 @@
+package algorithm
+
+import (
+	"math"
+)
+
+// Discounted UCB algorithm, works on counts and rewards that were discounted by their age.
+// With the sliding window discount the weights are either one or zero and it becomes sliding-window UCB
+type DiscountedUCB struct {
+	Counts  []float64
+	Rewards []float64
+	randomized
+}
+
+// NewDiscountedUCB returns a pointer to the DiscountedUCB struct,
+// rewards hold the discounted accumulated reward (number of clicks) of every arm
+func NewDiscountedUCB(counts []float64, rewards []float64) (*DiscountedUCB, error) {
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &DiscountedUCB{
+		Counts:  counts,
+		Rewards: rewards,
+	}, nil
+}
+
+// Reset will set the counts and rewards with the provided number of arms
+func (d *DiscountedUCB) Reset(nArms int) error {
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+
+	d.Counts = make([]float64, nArms)
+	d.Rewards = make([]float64, nArms)
+
+	return nil
+}
+
+// SelectArm chooses an arm with the greatest upper confidence bound,
+// every arm without observations left is chosen first
+func (d *DiscountedUCB) SelectArm() int {
+	if i := d.unpulled(d.Counts); i >= 0 {
+		return i
+	}
+
+	totalCounts := 0.0
+	for _, v := range d.Counts {
+		totalCounts += v
+	}
+
+	// the discounted total may fall below one when everything is forgotten
+	logTotal := math.Log(math.Max(totalCounts, 1))
+
+	ucbValues := make([]float64, len(d.Counts))
+	for i, count := range d.Counts {
+		ucbValues[i] = d.Rewards[i]/count + math.Sqrt(2*logTotal/count)
+	}
+
+	return d.argmax(ucbValues)
+}
+
+// Update will update an arm with some reward value
+func (d *DiscountedUCB) Update(chosenArm int, reward float64) error {
+	if chosenArm < 0 || chosenArm >= len(d.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	d.Counts[chosenArm]++
+	d.Rewards[chosenArm] += reward
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewDiscountedUCB(t *testing.T) {
+	_, err := NewDiscountedUCB(make([]float64, 3), make([]float64, 2))
+	assert.Equal(t, ErrInvalidLength, err)
+
+	discountedUCB, err := NewDiscountedUCB(make([]float64, 3), make([]float64, 3))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(discountedUCB.Counts))
+
+	assert.Equal(t, ErrInvalidArms, discountedUCB.Reset(0))
+	assert.Nil(t, discountedUCB.Reset(2))
+	assert.Equal(t, []float64{0, 0}, discountedUCB.Counts)
+}
+
+func TestDiscountedUCB_SelectArm(t *testing.T) {
+	discountedUCB, _ := NewDiscountedUCB([]float64{3.5, 0, 1}, []float64{1, 0, 1})
+	assert.Equal(t, 1, discountedUCB.SelectArm(), "arm without observations should be chosen first")
+
+	discountedUCB, _ = NewDiscountedUCB([]float64{500, 500}, []float64{50, 10})
+	assert.Equal(t, 0, discountedUCB.SelectArm(), "should exploit the arm with the best ctr")
+
+	discountedUCB, _ = NewDiscountedUCB([]float64{500, 0.01}, []float64{50, 0})
+	assert.Equal(t, 1, discountedUCB.SelectArm(), "should explore the arm that was forgotten")
+
+	discountedUCB, _ = NewDiscountedUCB([]float64{0.3, 0.2}, []float64{0.1, 0.2})
+	assert.Equal(t, 1, discountedUCB.SelectArm(), "should work when the total is below one")
+}
+
+func TestDiscountedUCB_Update(t *testing.T) {
+	discountedUCB, _ := NewDiscountedUCB(nil, nil)
+	discountedUCB.Reset(2)
+
+	assert.Equal(t, ErrArmsIndexOutOfRange, discountedUCB.Update(2, 1))
+	assert.Equal(t, ErrInvalidReward, discountedUCB.Update(0, -1))
+	assert.Nil(t, discountedUCB.Update(0, 1))
+	assert.Equal(t, []float64{1, 0}, discountedUCB.Counts)
+	assert.Equal(t, []float64{1, 0}, discountedUCB.Rewards)
+}
@@ -0,0 +1,127 @@
+package algorithm
+
+import (
+	"errors"
+	"math/rand"
+)
+
+var (
+	ErrInvalidEpsilon = errors.New("epsilon must be between zero and one")
+)
+
+// Epsilon-greedy algorithm
+type EpsilonGreedy struct {
+	Epsilon float64
+	Counts  []int
+	Rewards []float64
+	randomized
+}
+
+// NewEpsilonGreedy returns a pointer to the EpsilonGreedy struct,
+// rewards hold the accumulated reward (number of clicks) of every arm
+func NewEpsilonGreedy(
+	epsilon float64,
+	counts []int,
+	rewards []float64,
+	source rand.Source,
+) (*EpsilonGreedy, error) {
+	if epsilon < 0 || epsilon > 1 {
+		return nil, ErrInvalidEpsilon
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &EpsilonGreedy{
+		Epsilon:    epsilon,
+		Counts:     counts,
+		Rewards:    rewards,
+		randomized: randomized{rand: rand.New(source)},
+	}, nil
+}
+
+// Reset will set the counts and rewards with the provided number of arms
+func (e *EpsilonGreedy) Reset(nArms int) error {
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+
+	e.Counts = make([]int, nArms)
+	e.Rewards = make([]float64, nArms)
+
+	return nil
+}
+
+// SelectArm chooses an arm that exploits if the value is more than the epsilon
+// threshold, and explore if the value is less than epsilon
+func (e *EpsilonGreedy) SelectArm() int {
+	if len(e.Counts) == 0 {
+		return 0
+	}
+
+	if e.rand.Float64() < e.Epsilon {
+		return e.rand.Intn(len(e.Counts))
+	}
+
+	values := make([]float64, len(e.Counts))
+	for i := range e.Counts {
+		values[i] = mean(e.Rewards[i], e.Counts[i])
+	}
+
+	maxIndex := e.argmax(values)
+
+	return maxIndex
+}
+
+// Update will update an arm with some reward value
+func (e *EpsilonGreedy) Update(chosenArm int, reward float64) error {
+	if chosenArm < 0 || chosenArm >= len(e.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	e.Counts[chosenArm]++
+	e.Rewards[chosenArm] += reward
+
+	return nil
+}
+
+// Annealing epsilon-greedy algorithm, epsilon decays as the arms are pulled
+type AnnealingEpsilonGreedy struct {
+	EpsilonGreedy
+	Schedule Schedule
+}
+
+// NewAnnealingEpsilonGreedy returns a pointer to the AnnealingEpsilonGreedy struct
+func NewAnnealingEpsilonGreedy(
+	schedule Schedule,
+	counts []int,
+	rewards []float64,
+	source rand.Source,
+) (*AnnealingEpsilonGreedy, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	if schedule.Min > 1 {
+		return nil, ErrInvalidEpsilon
+	}
+
+	epsilonGreedy, err := NewEpsilonGreedy(schedule.Initial, counts, rewards, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnnealingEpsilonGreedy{
+		EpsilonGreedy: *epsilonGreedy,
+		Schedule:      schedule,
+	}, nil
+}
+
+// SelectArm decays epsilon by the total number of pulls and chooses an arm like epsilon-greedy
+func (a *AnnealingEpsilonGreedy) SelectArm() int {
+	a.Epsilon = a.Schedule.Value(total(a.Counts))
+
+	return a.EpsilonGreedy.SelectArm()
+}
@@ -0,0 +1,116 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestNewEpsilonGreedy(t *testing.T) {
+	testCases := map[string]struct {
+		epsilon float64
+		counts  []int
+		rewards []float64
+		err     error
+	}{
+		"nil value":         {0.1, nil, nil, nil},
+		"correct value":     {0.1, make([]int, 3), make([]float64, 3), nil},
+		"invalid length":    {0.1, make([]int, 3), make([]float64, 5), ErrInvalidLength},
+		"negative epsilon":  {-0.1, make([]int, 3), make([]float64, 3), ErrInvalidEpsilon},
+		"epsilon above one": {1.1, make([]int, 3), make([]float64, 3), ErrInvalidEpsilon},
+		"pure exploration":  {1, make([]int, 3), make([]float64, 3), nil},
+		"pure exploitation": {0, make([]int, 3), make([]float64, 3), nil},
+	}
+
+	for _, testCase := range testCases {
+		epsilonGreedy, err := NewEpsilonGreedy(testCase.epsilon, testCase.counts, testCase.rewards, rand.NewSource(1))
+
+		if testCase.err != nil {
+			assert.Equal(t, testCase.err, err, "errors should match")
+		} else {
+			assert.Nil(t, err, "errors should be nil")
+			assert.Equal(t, testCase.epsilon, epsilonGreedy.Epsilon, "epsilon should be equal")
+			assert.Equal(t, testCase.counts, epsilonGreedy.Counts, "counts should be equal")
+			assert.Equal(t, testCase.rewards, epsilonGreedy.Rewards, "rewards should be equal")
+		}
+	}
+}
+
+func TestEpsilonGreedy_Reset(t *testing.T) {
+	epsilonGreedy, _ := NewEpsilonGreedy(0.1, nil, nil, rand.NewSource(1))
+
+	assert.Equal(t, ErrInvalidArms, epsilonGreedy.Reset(0))
+	assert.Nil(t, epsilonGreedy.Reset(3))
+	assert.Equal(t, 3, len(epsilonGreedy.Counts))
+	assert.Equal(t, 3, len(epsilonGreedy.Rewards))
+}
+
+func TestEpsilonGreedy_SelectArm(t *testing.T) {
+	counts := []int{10, 10, 10}
+	rewards := []float64{1, 5, 2}
+
+	exploit, _ := NewEpsilonGreedy(0, counts, rewards, rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, 1, exploit.SelectArm(), "should always exploit the best arm")
+	}
+
+	explore, _ := NewEpsilonGreedy(1, counts, rewards, rand.NewSource(1))
+	selected := make(map[int]int)
+	for i := 0; i < 300; i++ {
+		selected[explore.SelectArm()]++
+	}
+	assert.Equal(t, 3, len(selected), "should explore every arm")
+}
+
+func TestEpsilonGreedy_Update(t *testing.T) {
+	epsilonGreedy, _ := NewEpsilonGreedy(0.1, nil, nil, rand.NewSource(1))
+	epsilonGreedy.Reset(2)
+
+	assert.Equal(t, ErrArmsIndexOutOfRange, epsilonGreedy.Update(2, 1))
+	assert.Equal(t, ErrInvalidReward, epsilonGreedy.Update(0, -1))
+	assert.Nil(t, epsilonGreedy.Update(1, 1))
+	assert.Nil(t, epsilonGreedy.Update(1, 0))
+	assert.Equal(t, []int{0, 2}, epsilonGreedy.Counts)
+	assert.Equal(t, []float64{0, 1}, epsilonGreedy.Rewards)
+}
+
+func TestNewAnnealingEpsilonGreedy(t *testing.T) {
+	testCases := map[string]struct {
+		schedule Schedule
+		err      error
+	}{
+		"inverse schedule":     {Schedule{Kind: ScheduleInverse, Initial: 1, Min: 0.01, Rate: 0.1}, nil},
+		"exponential schedule": {Schedule{Kind: ScheduleExponential, Initial: 0.5, Rate: 0.1}, nil},
+		"unknown schedule":     {Schedule{Kind: "unknown", Initial: 0.5}, ErrInvalidSchedule},
+		"negative rate":        {Schedule{Kind: ScheduleInverse, Initial: 0.5, Rate: -1}, ErrInvalidSchedule},
+		"invalid epsilon":      {Schedule{Kind: ScheduleInverse, Initial: 2}, ErrInvalidEpsilon},
+		"invalid min epsilon":  {Schedule{Kind: ScheduleInverse, Initial: 0.5, Min: 2}, ErrInvalidEpsilon},
+	}
+
+	for name, testCase := range testCases {
+		_, err := NewAnnealingEpsilonGreedy(testCase.schedule, []int{0, 0}, []float64{0, 0}, rand.NewSource(1))
+		assert.Equal(t, testCase.err, err, name)
+	}
+}
+
+func TestAnnealingEpsilonGreedy_SelectArm(t *testing.T) {
+	schedule := Schedule{Kind: ScheduleInverse, Initial: 1, Min: 0, Rate: 1}
+	annealing, _ := NewAnnealingEpsilonGreedy(schedule, []int{0, 0}, []float64{0, 0}, rand.NewSource(1))
+
+	annealing.SelectArm()
+	assert.Equal(t, 1.0, annealing.Epsilon, "should explore before the first pull")
+
+	annealing.Update(0, 1)
+	annealing.Update(1, 0)
+	annealing.Update(1, 0)
+	annealing.SelectArm()
+	assert.Equal(t, 0.25, annealing.Epsilon, "epsilon should decay with the pulls")
+
+	for i := 0; i < 10000; i++ {
+		annealing.Update(1, 0)
+	}
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, 0, annealing.SelectArm(), "should exploit once epsilon has decayed")
+	}
+}
@@ -0,0 +1,105 @@
+package algorithm
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+var (
+	ErrInvalidGamma = errors.New("gamma must be greater than zero and not greater than one")
+)
+
+// EXP3 algorithm for adversarial rewards
+type EXP3 struct {
+	Gamma      float64
+	Counts     []int
+	Rewards    []float64
+	LogWeights []float64
+	randomized
+}
+
+// NewEXP3 returns a pointer to the EXP3 struct, gamma is the share of uniform exploration.
+// Rewards hold the estimated cumulative reward of every arm, the sum of the rewards of its pulls
+// divided by the probabilities it was pulled with, the weights grow with the estimated rewards
+func NewEXP3(gamma float64, counts []int, rewards []float64, source rand.Source) (*EXP3, error) {
+	if gamma <= 0 || gamma > 1 {
+		return nil, ErrInvalidGamma
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	e := &EXP3{
+		Gamma:      gamma,
+		Counts:     counts,
+		Rewards:    rewards,
+		LogWeights: make([]float64, len(counts)),
+		randomized: randomized{rand: rand.New(source)},
+	}
+
+	for i := range counts {
+		e.LogWeights[i] = gamma * rewards[i] / float64(len(counts))
+	}
+
+	return e, nil
+}
+
+// Reset will set the counts, rewards and weights with the provided number of arms
+func (e *EXP3) Reset(nArms int) error {
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+
+	e.Counts = make([]int, nArms)
+	e.Rewards = make([]float64, nArms)
+	e.LogWeights = make([]float64, nArms)
+
+	return nil
+}
+
+// SelectArm chooses an arm with the probability mixed from its weight and the uniform exploration
+func (e *EXP3) SelectArm() int {
+	return categorical(e.rand, e.Probabilities())
+}
+
+// Probabilities returns the probability of selecting each arm
+func (e *EXP3) Probabilities() []float64 {
+	n := float64(len(e.LogWeights))
+	probabilities := make([]float64, len(e.LogWeights))
+
+	// subtracting the max weight keeps exp from overflowing
+	_, maxWeight := max(e.LogWeights)
+
+	sum := 0.0
+	for i, v := range e.LogWeights {
+		probabilities[i] = math.Exp(v - maxWeight)
+		sum += probabilities[i]
+	}
+
+	for i := range probabilities {
+		probabilities[i] = (1-e.Gamma)*probabilities[i]/sum + e.Gamma/n
+	}
+
+	return probabilities
+}
+
+// Update will update an arm with some reward value, the reward is weighted by the inverse
+// of the probability of the arm so that the estimate stays unbiased
+func (e *EXP3) Update(chosenArm int, reward float64) error {
+	if chosenArm < 0 || chosenArm >= len(e.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	probability := e.Probabilities()[chosenArm]
+	estimate := math.Min(reward, 1) / probability
+
+	e.LogWeights[chosenArm] += e.Gamma * estimate / float64(len(e.LogWeights))
+	e.Counts[chosenArm]++
+	e.Rewards[chosenArm] += estimate
+
+	return nil
+}
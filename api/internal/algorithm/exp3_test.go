@@ -0,0 +1,95 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestNewEXP3(t *testing.T) {
+	testCases := map[string]struct {
+		gamma   float64
+		counts  []int
+		rewards []float64
+		err     error
+	}{
+		"nil value":      {0.1, nil, nil, nil},
+		"correct value":  {0.1, make([]int, 3), make([]float64, 3), nil},
+		"full gamma":     {1, make([]int, 3), make([]float64, 3), nil},
+		"invalid length": {0.1, make([]int, 3), make([]float64, 5), ErrInvalidLength},
+		"zero gamma":     {0, make([]int, 3), make([]float64, 3), ErrInvalidGamma},
+		"too big gamma":  {1.5, make([]int, 3), make([]float64, 3), ErrInvalidGamma},
+	}
+
+	for name, testCase := range testCases {
+		exp3, err := NewEXP3(testCase.gamma, testCase.counts, testCase.rewards, rand.NewSource(1))
+
+		if testCase.err != nil {
+			assert.Equal(t, testCase.err, err, name)
+		} else {
+			assert.Nil(t, err, name)
+			assert.Equal(t, testCase.gamma, exp3.Gamma, name)
+			assert.Equal(t, testCase.counts, exp3.Counts, name)
+			assert.Equal(t, testCase.rewards, exp3.Rewards, name)
+		}
+	}
+}
+
+func TestEXP3_Probabilities(t *testing.T) {
+	equal, _ := NewEXP3(0.1, []int{10, 10}, []float64{20, 20}, rand.NewSource(1))
+	assert.InDeltaSlice(t, []float64{0.5, 0.5}, equal.Probabilities(), 1e-9, "equal rewards should split evenly")
+
+	exp3, _ := NewEXP3(0.1, []int{1000, 1000}, []float64{20, 200}, rand.NewSource(1))
+	probabilities := exp3.Probabilities()
+	assert.InDelta(t, 1, probabilities[0]+probabilities[1], 1e-9, "probabilities should sum to one")
+	assert.True(t, probabilities[1] > 0.9, "better arm should get the most of the traffic")
+	assert.True(t, probabilities[0] >= 0.05, "every arm should keep the uniform exploration")
+
+	uniform, _ := NewEXP3(1, []int{1000, 1000}, []float64{20, 200}, rand.NewSource(1))
+	assert.InDeltaSlice(t, []float64{0.5, 0.5}, uniform.Probabilities(), 1e-9, "full gamma should be uniform")
+}
+
+func TestNewEXP3Estimates(t *testing.T) {
+	// the arm pulled rarely with the same clicks has the bigger estimated reward
+	exp3, _ := NewEXP3(0.1, []int{900, 100}, []float64{10 / 0.9, 10 / 0.1}, rand.NewSource(1))
+	assert.InDeltaSlice(t, []float64{0.1 * 10 / 0.9 / 2, 0.1 * 10 / 0.1 / 2}, exp3.LogWeights, 1e-9)
+
+	restored, _ := NewEXP3(0.1, nil, nil, rand.NewSource(1))
+	restored.Reset(2)
+	assert.Nil(t, restored.Update(0, 1))
+	assert.Nil(t, restored.Update(1, 1))
+
+	rebuilt, _ := NewEXP3(0.1, restored.Counts, restored.Rewards, rand.NewSource(1))
+	assert.InDeltaSlice(t, restored.LogWeights, rebuilt.LogWeights, 1e-9, "the weights should be rebuilt from the estimates")
+}
+
+func TestEXP3_SelectArm(t *testing.T) {
+	exp3, _ := NewEXP3(0.1, nil, nil, rand.NewSource(1))
+	exp3.Reset(3)
+
+	for i := 0; i < 3000; i++ {
+		arm := exp3.SelectArm()
+
+		reward := 0.0
+		if arm == 2 {
+			reward = 1
+		}
+
+		assert.Nil(t, exp3.Update(arm, reward))
+	}
+
+	assert.True(t, exp3.Counts[2] > 2000, "best arm should be selected most of the time")
+}
+
+func TestEXP3_Update(t *testing.T) {
+	exp3, _ := NewEXP3(0.1, nil, nil, rand.NewSource(1))
+	exp3.Reset(2)
+
+	assert.Equal(t, ErrArmsIndexOutOfRange, exp3.Update(2, 1))
+	assert.Equal(t, ErrInvalidReward, exp3.Update(0, -1))
+	assert.Nil(t, exp3.Update(0, 1))
+	assert.Equal(t, []int{1, 0}, exp3.Counts)
+	assert.Equal(t, []float64{2, 0}, exp3.Rewards, "reward should be divided by the probability of the arm")
+	assert.InDelta(t, 0.1, exp3.LogWeights[0], 1e-9, "weight should grow by the estimated reward")
+	assert.Equal(t, ErrInvalidArms, exp3.Reset(0))
+}
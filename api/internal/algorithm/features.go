@@ -0,0 +1,29 @@
+package algorithm
+
+import (
+	"hash/fnv"
+)
+
+// HashFeatures encodes the key/value attributes of a request into a feature vector of the given dimension.
+// The first feature is the bias, every attribute sets the feature that its hash points to
+func HashFeatures(attributes map[string]string, dimension int) []float64 {
+	if dimension < 1 {
+		return nil
+	}
+
+	features := make([]float64, dimension)
+	features[0] = 1
+
+	if dimension == 1 {
+		return features
+	}
+
+	for key, value := range attributes {
+		h := fnv.New32a()
+		h.Write([]byte(key + "=" + value))
+
+		features[1+int(h.Sum32()%uint32(dimension-1))]++
+	}
+
+	return features
+}
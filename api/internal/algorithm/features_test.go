@@ -0,0 +1,31 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHashFeatures(t *testing.T) {
+	assert.Nil(t, HashFeatures(map[string]string{"device": "mobile"}, 0))
+	assert.Equal(t, []float64{1}, HashFeatures(map[string]string{"device": "mobile"}, 1))
+	assert.Equal(t, []float64{1, 0, 0, 0}, HashFeatures(nil, 4), "only the bias without attributes")
+
+	attributes := map[string]string{"device": "mobile", "hour": "13", "referrer": "google"}
+	features := HashFeatures(attributes, 32)
+
+	assert.Equal(t, 32, len(features))
+	assert.Equal(t, 1.0, features[0], "first feature should be the bias")
+
+	sum := 0.0
+	for _, v := range features[1:] {
+		sum += v
+	}
+	assert.Equal(t, 3.0, sum, "every attribute should set one feature")
+
+	assert.Equal(t, features, HashFeatures(attributes, 32), "encoding should be stable")
+	assert.NotEqual(
+		t,
+		HashFeatures(map[string]string{"device": "mobile"}, 32),
+		HashFeatures(map[string]string{"device": "desktop"}, 32),
+	)
+}
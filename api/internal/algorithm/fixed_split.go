@@ -0,0 +1,61 @@
+package algorithm
+
+import (
+	"math/rand"
+)
+
+// Fixed split algorithm, splits the traffic evenly between the arms like an A/B test
+type FixedSplit struct {
+	Counts  []int
+	Rewards []float64
+	randomized
+}
+
+// NewFixedSplit returns a pointer to the FixedSplit struct
+func NewFixedSplit(counts []int, rewards []float64, source rand.Source) (*FixedSplit, error) {
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &FixedSplit{
+		Counts:     counts,
+		Rewards:    rewards,
+		randomized: randomized{rand: rand.New(source)},
+	}, nil
+}
+
+// Reset will set the counts and rewards with the provided number of arms
+func (f *FixedSplit) Reset(nArms int) error {
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+
+	f.Counts = make([]int, nArms)
+	f.Rewards = make([]float64, nArms)
+
+	return nil
+}
+
+// SelectArm chooses an arm uniformly at random regardless of the rewards
+func (f *FixedSplit) SelectArm() int {
+	if len(f.Counts) == 0 {
+		return 0
+	}
+
+	return f.rand.Intn(len(f.Counts))
+}
+
+// Update will update an arm with some reward value
+func (f *FixedSplit) Update(chosenArm int, reward float64) error {
+	if chosenArm < 0 || chosenArm >= len(f.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	f.Counts[chosenArm]++
+	f.Rewards[chosenArm] += reward
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestNewFixedSplit(t *testing.T) {
+	_, err := NewFixedSplit(make([]int, 3), make([]float64, 2), rand.NewSource(1))
+	assert.Equal(t, ErrInvalidLength, err)
+
+	fixedSplit, err := NewFixedSplit(make([]int, 3), make([]float64, 3), rand.NewSource(1))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(fixedSplit.Counts))
+}
+
+func TestFixedSplit_SelectArm(t *testing.T) {
+	fixedSplit, _ := NewFixedSplit([]int{100, 100, 100}, []float64{1, 90, 2}, rand.NewSource(1))
+
+	n := 30000
+	selected := make([]int, 3)
+	for i := 0; i < n; i++ {
+		selected[fixedSplit.SelectArm()]++
+	}
+
+	for _, count := range selected {
+		assert.InDelta(t, float64(n)/3, float64(count), float64(n)/30, "traffic should be split evenly")
+	}
+}
+
+func TestFixedSplit_Update(t *testing.T) {
+	fixedSplit, _ := NewFixedSplit(nil, nil, rand.NewSource(1))
+	fixedSplit.Reset(2)
+
+	assert.Equal(t, ErrArmsIndexOutOfRange, fixedSplit.Update(2, 1))
+	assert.Equal(t, ErrInvalidReward, fixedSplit.Update(1, -1))
+	assert.Nil(t, fixedSplit.Update(1, 1))
+	assert.Equal(t, []int{0, 1}, fixedSplit.Counts)
+	assert.Equal(t, []float64{0, 1}, fixedSplit.Rewards)
+}
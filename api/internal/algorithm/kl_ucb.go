@@ -0,0 +1,109 @@
+package algorithm
+
+import (
+	"math"
+)
+
+const (
+	// Precision of the search for the upper confidence bound
+	klPrecision = 1e-6
+
+	// Keeps the logarithms of the kullback-leibler divergence finite
+	klEpsilon = 1e-15
+)
+
+// KL-UCB algorithm for bernoulli rewards
+type KLUCB struct {
+	// Constant of the log(log(t)) term of the exploration rate, zero is recommended in practice
+	C       float64
+	Counts  []int
+	Rewards []float64
+	randomized
+}
+
+// NewKLUCB returns a pointer to the KLUCB struct,
+// rewards hold the accumulated reward (number of clicks) of every arm
+func NewKLUCB(c float64, counts []int, rewards []float64) (*KLUCB, error) {
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &KLUCB{
+		C:       c,
+		Counts:  counts,
+		Rewards: rewards,
+	}, nil
+}
+
+// Reset will set the counts and rewards with the provided number of arms
+func (k *KLUCB) Reset(nArms int) error {
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+
+	k.Counts = make([]int, nArms)
+	k.Rewards = make([]float64, nArms)
+
+	return nil
+}
+
+// SelectArm chooses an arm with the greatest kullback-leibler upper confidence bound,
+// every arm that has never been pulled is chosen first
+func (k *KLUCB) SelectArm() int {
+	if i := k.unpulled(floatCounts(k.Counts)); i >= 0 {
+		return i
+	}
+
+	t := float64(total(k.Counts))
+	exploration := math.Log(t)
+	if t > math.E {
+		exploration += k.C * math.Log(math.Log(t))
+	}
+
+	ucbValues := make([]float64, len(k.Counts))
+	for i, count := range k.Counts {
+		p := math.Min(mean(k.Rewards[i], count), 1)
+		ucbValues[i] = klUpperBound(p, exploration/float64(count))
+	}
+
+	return k.argmax(ucbValues)
+}
+
+// Update will update an arm with some reward value
+func (k *KLUCB) Update(chosenArm int, reward float64) error {
+	if chosenArm < 0 || chosenArm >= len(k.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	k.Counts[chosenArm]++
+	k.Rewards[chosenArm] += reward
+
+	return nil
+}
+
+// Returns the greatest q in [p, 1] with kl(p, q) <= bound, found by bisection
+func klUpperBound(p float64, bound float64) float64 {
+	low, high := p, 1.0
+
+	for high-low > klPrecision {
+		q := (low + high) / 2
+		if klBernoulli(p, q) > bound {
+			high = q
+		} else {
+			low = q
+		}
+	}
+
+	return (low + high) / 2
+}
+
+// Returns the kullback-leibler divergence of two bernoulli distributions
+func klBernoulli(p float64, q float64) float64 {
+	p = math.Min(math.Max(p, klEpsilon), 1-klEpsilon)
+	q = math.Min(math.Max(q, klEpsilon), 1-klEpsilon)
+
+	return p*math.Log(p/q) + (1-p)*math.Log((1-p)/(1-q))
+}
@@ -0,0 +1,72 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestNewKLUCB(t *testing.T) {
+	_, err := NewKLUCB(0, make([]int, 3), make([]float64, 2))
+	assert.Equal(t, ErrInvalidLength, err)
+
+	klUCB, err := NewKLUCB(3, make([]int, 3), make([]float64, 3))
+	assert.Nil(t, err)
+	assert.Equal(t, 3.0, klUCB.C)
+
+	assert.Equal(t, ErrInvalidArms, klUCB.Reset(-1))
+	assert.Nil(t, klUCB.Reset(2))
+	assert.Equal(t, []float64{0, 0}, klUCB.Rewards)
+}
+
+func TestKLUCB_SelectArm(t *testing.T) {
+	klUCB, _ := NewKLUCB(0, []int{10, 10, 0}, []float64{1, 2, 0})
+	assert.Equal(t, 2, klUCB.SelectArm(), "arm without pulls should be chosen first")
+
+	klUCB, _ = NewKLUCB(0, []int{5000, 5000, 5000}, []float64{50, 100, 25})
+	assert.Equal(t, 1, klUCB.SelectArm(), "should exploit the arm with the best ctr")
+
+	klUCB, _ = NewKLUCB(0, []int{10000, 20}, []float64{200, 0})
+	assert.Equal(t, 1, klUCB.SelectArm(), "should explore the arm with few pulls")
+}
+
+func TestKLUCB_Update(t *testing.T) {
+	klUCB, _ := NewKLUCB(0, nil, nil)
+	klUCB.Reset(2)
+
+	assert.Equal(t, ErrArmsIndexOutOfRange, klUCB.Update(-1, 1))
+	assert.Equal(t, ErrInvalidReward, klUCB.Update(0, -1))
+	assert.Nil(t, klUCB.Update(1, 1))
+	assert.Equal(t, []int{0, 1}, klUCB.Counts)
+}
+
+func TestKLBernoulli(t *testing.T) {
+	assert.InDelta(t, 0, klBernoulli(0.3, 0.3), 1e-12)
+	assert.InDelta(t, 0.5*math.Log(0.5/0.25)+0.5*math.Log(0.5/0.75), klBernoulli(0.5, 0.25), 1e-12)
+	assert.False(t, math.IsInf(klBernoulli(0, 1), 0), "divergence should stay finite")
+}
+
+func TestKLUpperBound(t *testing.T) {
+	testCases := []struct {
+		p     float64
+		bound float64
+	}{
+		{0.01, 0.001},
+		{0.02, 0.01},
+		{0.5, 0.1},
+		{0, 0.5},
+	}
+
+	for _, testCase := range testCases {
+		q := klUpperBound(testCase.p, testCase.bound)
+
+		assert.True(t, q >= testCase.p && q <= 1, "bound should be in [p, 1]")
+		assert.InDelta(t, testCase.bound, klBernoulli(testCase.p, q), 1e-4, "divergence should reach the bound")
+	}
+
+	assert.True(
+		t,
+		klUpperBound(0.01, 0.001)-0.01 < math.Sqrt(0.001/2),
+		"bound should be tighter than hoeffding at low ctr",
+	)
+}
@@ -0,0 +1,182 @@
+package algorithm
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrInvalidDimension = errors.New("dimension must be greater than zero")
+	ErrInvalidFeatures  = errors.New("features must be of the algorithm dimension")
+	ErrInvalidAlpha     = errors.New("alpha must not be negative")
+)
+
+// Contextual algorithm learns the reward of the arms as a function of the request features
+type Contextual interface {
+	Algorithm
+
+	// Dimension of the feature vectors
+	Dimension() int
+
+	// SetContext sets the features of the request the next arm is selected for
+	SetContext(features []float64) error
+
+	// Observe adds the pulls and the reward of the arm observed with the features
+	Observe(arm int, features []float64, pulls float64, reward float64) error
+}
+
+// LinUCB algorithm with disjoint linear models of the arms
+type LinUCB struct {
+	Alpha float64
+
+	// Inverse of the design matrix of every arm, stored by rows
+	AInv [][]float64
+
+	// Reward weighted sum of the features of every arm
+	B [][]float64
+
+	dimension int
+	context   []float64
+	randomized
+}
+
+// NewLinUCB returns a pointer to the LinUCB struct with the provided number of arms,
+// alpha controls the width of the confidence bound
+func NewLinUCB(alpha float64, dimension int, nArms int) (*LinUCB, error) {
+	if alpha < 0 {
+		return nil, ErrInvalidAlpha
+	}
+	if dimension < 1 {
+		return nil, ErrInvalidDimension
+	}
+
+	l := &LinUCB{
+		Alpha:     alpha,
+		dimension: dimension,
+	}
+
+	if nArms > 0 {
+		if err := l.Reset(nArms); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// Reset will set the models with the provided number of arms
+func (l *LinUCB) Reset(nArms int) error {
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+
+	l.AInv = make([][]float64, nArms)
+	l.B = make([][]float64, nArms)
+
+	for i := 0; i < nArms; i++ {
+		l.AInv[i] = identity(l.dimension)
+		l.B[i] = make([]float64, l.dimension)
+	}
+
+	return nil
+}
+
+// Dimension of the feature vectors
+func (l *LinUCB) Dimension() int {
+	return l.dimension
+}
+
+// SetContext sets the features of the request the next arm is selected for
+func (l *LinUCB) SetContext(features []float64) error {
+	if len(features) != l.dimension {
+		return ErrInvalidFeatures
+	}
+
+	l.context = features
+
+	return nil
+}
+
+// SelectArm chooses an arm with the greatest upper confidence bound of the expected reward in the context
+func (l *LinUCB) SelectArm() int {
+	if l.context == nil {
+		l.context = make([]float64, l.dimension)
+		l.context[0] = 1
+	}
+
+	ucbValues := make([]float64, len(l.AInv))
+	for i := range l.AInv {
+		theta := mulMatVec(l.AInv[i], l.B[i], l.dimension)
+		aInvX := mulMatVec(l.AInv[i], l.context, l.dimension)
+
+		ucbValues[i] = dot(theta, l.context) + l.Alpha*math.Sqrt(math.Max(dot(l.context, aInvX), 0))
+	}
+
+	return l.argmax(ucbValues)
+}
+
+// Update will update an arm with some reward value observed in the current context
+func (l *LinUCB) Update(chosenArm int, reward float64) error {
+	return l.Observe(chosenArm, l.context, 1, reward)
+}
+
+// Observe adds the pulls and the reward of the arm observed with the features
+func (l *LinUCB) Observe(arm int, features []float64, pulls float64, reward float64) error {
+	if arm < 0 || arm >= len(l.AInv) {
+		return ErrArmsIndexOutOfRange
+	}
+	if len(features) != l.dimension {
+		return ErrInvalidFeatures
+	}
+	if reward < 0 || pulls < 0 {
+		return ErrInvalidReward
+	}
+
+	if pulls > 0 {
+		// Sherman-Morrison update of the inverse after adding pulls * x * x^T
+		aInvX := mulMatVec(l.AInv[arm], features, l.dimension)
+		denominator := 1/pulls + dot(features, aInvX)
+
+		for r := 0; r < l.dimension; r++ {
+			for c := 0; c < l.dimension; c++ {
+				l.AInv[arm][r*l.dimension+c] -= aInvX[r] * aInvX[c] / denominator
+			}
+		}
+	}
+
+	for i, v := range features {
+		l.B[arm][i] += reward * v
+	}
+
+	return nil
+}
+
+// Returns the identity matrix stored by rows
+func identity(dimension int) []float64 {
+	m := make([]float64, dimension*dimension)
+	for i := 0; i < dimension; i++ {
+		m[i*dimension+i] = 1
+	}
+
+	return m
+}
+
+// Multiplies the matrix stored by rows by the vector
+func mulMatVec(m []float64, v []float64, dimension int) []float64 {
+	result := make([]float64, dimension)
+	for r := 0; r < dimension; r++ {
+		result[r] = dot(m[r*dimension:(r+1)*dimension], v)
+	}
+
+	return result
+}
+
+// Returns the dot product of the vectors
+func dot(a []float64, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+
+	return sum
+}
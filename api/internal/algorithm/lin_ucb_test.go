@@ -0,0 +1,90 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewLinUCB(t *testing.T) {
+	testCases := map[string]struct {
+		alpha     float64
+		dimension int
+		arms      int
+		err       error
+	}{
+		"correct value":     {1, 4, 3, nil},
+		"without arms":      {1, 4, 0, nil},
+		"negative alpha":    {-1, 4, 3, ErrInvalidAlpha},
+		"invalid dimension": {1, 0, 3, ErrInvalidDimension},
+	}
+
+	for name, testCase := range testCases {
+		linUCB, err := NewLinUCB(testCase.alpha, testCase.dimension, testCase.arms)
+
+		if testCase.err != nil {
+			assert.Equal(t, testCase.err, err, name)
+		} else {
+			assert.Nil(t, err, name)
+			assert.Equal(t, testCase.dimension, linUCB.Dimension(), name)
+			assert.Equal(t, testCase.arms, len(linUCB.AInv), name)
+		}
+	}
+}
+
+func TestLinUCB_Reset(t *testing.T) {
+	linUCB, _ := NewLinUCB(1, 2, 0)
+
+	assert.Equal(t, ErrInvalidArms, linUCB.Reset(0))
+	assert.Nil(t, linUCB.Reset(2))
+	assert.Equal(t, [][]float64{{1, 0, 0, 1}, {1, 0, 0, 1}}, linUCB.AInv)
+	assert.Equal(t, [][]float64{{0, 0}, {0, 0}}, linUCB.B)
+}
+
+func TestLinUCB_Observe(t *testing.T) {
+	linUCB, _ := NewLinUCB(1, 2, 2)
+
+	assert.Equal(t, ErrArmsIndexOutOfRange, linUCB.Observe(2, []float64{1, 0}, 1, 0))
+	assert.Equal(t, ErrInvalidFeatures, linUCB.Observe(0, []float64{1}, 1, 0))
+	assert.Equal(t, ErrInvalidReward, linUCB.Observe(0, []float64{1, 0}, 1, -1))
+
+	// A = I + 3 * x * x^T with x = (1, 1), the inverse is (1/7) * [[4, -3], [-3, 4]]
+	assert.Nil(t, linUCB.Observe(0, []float64{1, 1}, 3, 2))
+	expected := []float64{4.0 / 7, -3.0 / 7, -3.0 / 7, 4.0 / 7}
+	for i, v := range expected {
+		assert.InDelta(t, v, linUCB.AInv[0][i], 1e-9)
+	}
+	assert.Equal(t, []float64{2, 2}, linUCB.B[0])
+
+	assert.Nil(t, linUCB.Observe(1, []float64{0, 1}, 0, 1), "reward without pulls should only change b")
+	assert.Equal(t, []float64{1, 0, 0, 1}, linUCB.AInv[1])
+	assert.Equal(t, []float64{0, 1}, linUCB.B[1])
+}
+
+func TestLinUCB_SelectArm(t *testing.T) {
+	linUCB, _ := NewLinUCB(0.1, 3, 2)
+
+	mobile := []float64{1, 1, 0}
+	desktop := []float64{1, 0, 1}
+
+	for i := 0; i < 100; i++ {
+		linUCB.Observe(0, mobile, 1, 0)
+		linUCB.Observe(1, mobile, 1, 0)
+		linUCB.Observe(0, desktop, 1, 0)
+		linUCB.Observe(1, desktop, 1, 0)
+	}
+	for i := 0; i < 30; i++ {
+		linUCB.Observe(0, mobile, 0, 1)
+		linUCB.Observe(1, desktop, 0, 1)
+	}
+
+	assert.Equal(t, ErrInvalidFeatures, linUCB.SetContext([]float64{1}))
+
+	assert.Nil(t, linUCB.SetContext(mobile))
+	assert.Equal(t, 0, linUCB.SelectArm(), "should choose the arm clicked on mobile")
+
+	assert.Nil(t, linUCB.SetContext(desktop))
+	assert.Equal(t, 1, linUCB.SelectArm(), "should choose the arm clicked on desktop")
+
+	assert.Nil(t, linUCB.Update(1, 1))
+	assert.Equal(t, []float64{30 + 1, 0, 30 + 1}, linUCB.B[1], "update should use the current context")
+}
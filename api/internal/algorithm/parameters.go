@@ -0,0 +1,30 @@
+package algorithm
+
+const (
+	// Exploration rate of the epsilon-greedy strategies
+	ParameterEpsilon = "epsilon"
+
+	// Temperature of the softmax strategies
+	ParameterTemperature = "temperature"
+
+	// Constant of the KL-UCB exploration rate
+	ParameterKLUCBConstant = "c"
+
+	// Width of the confidence bound of the LinUCB strategy
+	ParameterAlpha = "alpha"
+
+	// Share of uniform exploration of the EXP3 strategy
+	ParameterGamma = "gamma"
+)
+
+// Parameters of the strategy set for a slot, they override the configured values
+type Parameters map[string]float64
+
+// Get returns the parameter or the fallback if the parameter is not set
+func (p Parameters) Get(name string, fallback float64) float64 {
+	if v, has := p[name]; has {
+		return v
+	}
+
+	return fallback
+}
@@ -0,0 +1,157 @@
+package algorithm
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// Samples a value from the beta distribution with the given shape parameters
+func betaSample(r *rand.Rand, alpha float64, beta float64) float64 {
+	x := gammaSample(r, alpha)
+	y := gammaSample(r, beta)
+
+	if x+y == 0 {
+		return 0
+	}
+
+	return x / (x + y)
+}
+
+// Samples a value from the gamma distribution with the given shape and unit scale,
+// uses the Marsaglia and Tsang method
+func gammaSample(r *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		return gammaSample(r, shape+1) * math.Pow(r.Float64(), 1/shape)
+	}
+
+	d := shape - 1.0/3.0
+	c := 1 / math.Sqrt(9*d)
+
+	for {
+		x := r.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+
+		v = v * v * v
+		u := r.Float64()
+
+		if u < 1-0.0331*x*x*x*x {
+			return d * v
+		}
+		if math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// Source of random numbers that is safe for concurrent use
+type lockedSource struct {
+	sync.Mutex
+	source rand.Source64
+}
+
+// NewLockedSource returns a seeded random source that is safe for concurrent use
+func NewLockedSource(seed int64) rand.Source {
+	return &lockedSource{source: rand.NewSource(seed).(rand.Source64)}
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer
+func (s *lockedSource) Int63() int64 {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.source.Int63()
+}
+
+// Uint64 returns a pseudo-random 64-bit integer
+func (s *lockedSource) Uint64() uint64 {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.source.Uint64()
+}
+
+// Seed uses the provided seed value to initialize the source
+func (s *lockedSource) Seed(seed int64) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.source.Seed(seed)
+}
+
+// Samples an index from the categorical distribution with the given probabilities
+func categorical(r *rand.Rand, probabilities []float64) int {
+	if len(probabilities) == 0 {
+		return 0
+	}
+
+	u := r.Float64()
+	cumulative := 0.0
+
+	for i, p := range probabilities {
+		cumulative += p
+		if u < cumulative {
+			return i
+		}
+	}
+
+	return len(probabilities) - 1
+}
+
+// Random source of the algorithm, it breaks the ties between the arms with equal scores.
+// Without a source the ties are broken in favor of the first arm
+type randomized struct {
+	rand *rand.Rand
+}
+
+// SetSource sets the random source of the algorithm
+func (r *randomized) SetSource(source rand.Source) {
+	r.rand = rand.New(source)
+}
+
+// Returns one of the indexes chosen at random, -1 if there are no indexes
+func (r *randomized) choice(indexes []int) int {
+	if len(indexes) == 0 {
+		return -1
+	}
+
+	if r.rand == nil {
+		return indexes[0]
+	}
+
+	return indexes[r.rand.Intn(len(indexes))]
+}
+
+// Returns the index of the greatest value, the ties are broken at random
+func (r *randomized) argmax(d []float64) int {
+	_, value := max(d)
+
+	indexes := make([]int, 0, 1)
+	for i, v := range d {
+		if v == value {
+			indexes = append(indexes, i)
+		}
+	}
+
+	index := r.choice(indexes)
+	if index < 0 {
+		return 0
+	}
+
+	return index
+}
+
+// Returns the index of an arm that has never been pulled, chosen at random, -1 if every arm was pulled
+func (r *randomized) unpulled(counts []float64) int {
+	indexes := make([]int, 0)
+	for i, v := range counts {
+		if v <= 0 {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return r.choice(indexes)
+}
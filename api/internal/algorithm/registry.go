@@ -0,0 +1,361 @@
+package algorithm
+
+import (
+	"errors"
+	"github.com/koind/banner-rotation/api/internal/config"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// Upper confidence bound strategy
+	StrategyUCB1 = "ucb1"
+
+	// Upper confidence bound strategy that takes the variance into account
+	StrategyUCB1Tuned = "ucb1-tuned"
+
+	// Kullback-Leibler upper confidence bound strategy for bernoulli rewards
+	StrategyKLUCB = "kl-ucb"
+
+	// Upper confidence bound strategy over the observations within a sliding window
+	StrategySlidingWindowUCB = "sliding-window-ucb"
+
+	// Upper confidence bound strategy that down-weights old observations
+	StrategyDiscountedUCB = "discounted-ucb"
+
+	// Thompson Sampling strategy
+	StrategyThompson = "thompson"
+
+	// Epsilon-greedy strategy
+	StrategyEpsilonGreedy = "epsilon-greedy"
+
+	// Epsilon-greedy strategy with decaying epsilon
+	StrategyAnnealingEpsilonGreedy = "annealing-epsilon-greedy"
+
+	// Softmax (Boltzmann exploration) strategy
+	StrategySoftmax = "softmax"
+
+	// Softmax strategy with decaying temperature
+	StrategyAnnealingSoftmax = "annealing-softmax"
+
+	// Even traffic split strategy
+	StrategyFixedSplit = "fixed-split"
+
+	// Contextual strategy with linear payoffs of the request features
+	StrategyLinUCB = "lin-ucb"
+
+	// Exponential-weight strategy for adversarial rewards
+	StrategyEXP3 = "exp3"
+
+	// Strategy used when the slot has no strategy of its own
+	DefaultStrategy = StrategyUCB1
+)
+
+var (
+	ErrUnknownStrategy       = errors.New("unknown strategy")
+	ErrContextualStrategy    = errors.New("strategy is contextual")
+	ErrNotContextualStrategy = errors.New("strategy is not contextual")
+)
+
+// Factory creates the algorithm for the arms with the given counts and total rewards,
+// the counts are fractional when the strategy discounts the observations
+type Factory func(counts []float64, rewards []float64, params Parameters) (Algorithm, error)
+
+// ContextualFactory creates the contextual algorithm for the provided number of arms
+type ContextualFactory func(nArms int, params Parameters) (Contextual, error)
+
+// Named strategy
+type strategy struct {
+	factory    Factory
+	discount   Discount
+	weighted   bool
+	contextual ContextualFactory
+}
+
+// Registry of the named strategies
+type Registry struct {
+	sync.RWMutex
+	strategies map[string]strategy
+	source     rand.Source
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		strategies: make(map[string]strategy),
+	}
+}
+
+// NewDefaultRegistry returns the registry with all the built-in strategies,
+// the random source is shared by the randomized strategies and breaks the ties of all the strategies
+func NewDefaultRegistry(source rand.Source, options config.Strategies) *Registry {
+	r := NewRegistry()
+	r.SetSource(source)
+	slidingWindow := time.Duration(options.SlidingWindow) * time.Hour
+	halfLife := time.Duration(options.HalfLife) * time.Hour
+	newDiscountedUCB := func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		return NewDiscountedUCB(counts, rewards)
+	}
+
+	r.Register(StrategyUCB1, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		// UCB1 keeps the average reward of every arm
+		rounded := roundCounts(counts)
+
+		return NewUCB1(rounded, meanRewards(rounded, rewards))
+	})
+	r.Register(StrategyUCB1Tuned, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		return NewUCB1Tuned(roundCounts(counts), rewards)
+	})
+	r.Register(StrategyKLUCB, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		c := params.Get(ParameterKLUCBConstant, options.KLUCBConstant)
+
+		return NewKLUCB(c, roundCounts(counts), rewards)
+	})
+	r.RegisterDiscounted(StrategySlidingWindowUCB, SlidingWindow(slidingWindow), newDiscountedUCB)
+	r.RegisterDiscounted(StrategyDiscountedUCB, HalfLife(halfLife), newDiscountedUCB)
+	r.Register(StrategyThompson, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		return NewThompsonSampling(roundCounts(counts), rewards, source)
+	})
+	r.Register(StrategyEpsilonGreedy, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		epsilon := params.Get(ParameterEpsilon, options.Epsilon)
+
+		return NewEpsilonGreedy(epsilon, roundCounts(counts), rewards, source)
+	})
+	r.Register(StrategyAnnealingEpsilonGreedy, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		schedule := Schedule{
+			Kind:    options.EpsilonDecay,
+			Initial: params.Get(ParameterEpsilon, options.Epsilon),
+			Min:     options.MinEpsilon,
+			Rate:    options.EpsilonDecayRate,
+		}
+
+		return NewAnnealingEpsilonGreedy(schedule, roundCounts(counts), rewards, source)
+	})
+	r.Register(StrategySoftmax, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		temperature := params.Get(ParameterTemperature, options.Temperature)
+
+		return NewSoftmax(temperature, roundCounts(counts), rewards, source)
+	})
+	r.Register(StrategyAnnealingSoftmax, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		schedule := Schedule{
+			Kind:    options.TemperatureDecay,
+			Initial: params.Get(ParameterTemperature, options.Temperature),
+			Min:     options.MinTemperature,
+			Rate:    options.TemperatureDecayRate,
+		}
+
+		return NewAnnealingSoftmax(schedule, roundCounts(counts), rewards, source)
+	})
+	r.Register(StrategyFixedSplit, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		return NewFixedSplit(roundCounts(counts), rewards, source)
+	})
+	r.RegisterWeighted(StrategyEXP3, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		gamma := params.Get(ParameterGamma, options.EXP3Gamma)
+
+		return NewEXP3(gamma, roundCounts(counts), rewards, source)
+	})
+	r.RegisterContextual(StrategyLinUCB, func(nArms int, params Parameters) (Contextual, error) {
+		alpha := params.Get(ParameterAlpha, options.LinUCBAlpha)
+
+		return NewLinUCB(alpha, options.LinUCBDimension, nArms)
+	})
+
+	return r
+}
+
+// SetSource sets the random source injected into every algorithm created by the registry,
+// the source must be safe for concurrent use
+func (r *Registry) SetSource(source rand.Source) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.source = source
+}
+
+// Source returns the random source injected into every algorithm, nil if it is not set
+func (r *Registry) Source() rand.Source {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.source
+}
+
+// Register adds the strategy to the registry, replaces the strategy with the same name
+func (r *Registry) Register(name string, factory Factory) {
+	r.RegisterDiscounted(name, nil, factory)
+}
+
+// RegisterDiscounted adds the strategy that discounts the observations by their age
+func (r *Registry) RegisterDiscounted(name string, discount Discount, factory Factory) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.strategies[name] = strategy{
+		factory:  factory,
+		discount: discount,
+	}
+}
+
+// RegisterWeighted adds the strategy that learns from the estimated rewards of the arms,
+// the rewards are divided by the probabilities the arms were selected with
+func (r *Registry) RegisterWeighted(name string, factory Factory) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.strategies[name] = strategy{
+		factory:  factory,
+		weighted: true,
+	}
+}
+
+// RegisterContextual adds the strategy that selects the arms by the request features
+func (r *Registry) RegisterContextual(name string, factory ContextualFactory) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.strategies[name] = strategy{
+		contextual: factory,
+	}
+}
+
+// Has reports whether the strategy is registered
+func (r *Registry) Has(name string) bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	_, has := r.strategies[name]
+
+	return has
+}
+
+// Names returns the sorted names of the registered strategies
+func (r *Registry) Names() []string {
+	r.RLock()
+	defer r.RUnlock()
+
+	names := make([]string, 0, len(r.strategies))
+	for name := range r.strategies {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// IsContextual reports whether the strategy selects the arms by the request features
+func (r *Registry) IsContextual(name string) bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.strategies[name].contextual != nil
+}
+
+// IsWeighted reports whether the strategy learns from the rewards divided by the probabilities of the arms
+func (r *Registry) IsWeighted(name string) bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.strategies[name].weighted
+}
+
+// Discount returns the discount of the named strategy, nil if the strategy keeps all the observations
+func (r *Registry) Discount(name string) Discount {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.strategies[name].discount
+}
+
+// Horizon returns the longest age the discounting strategies weigh the observations at,
+// reports false when one of them never forgets the observations
+func (r *Registry) Horizon() (time.Duration, bool) {
+	r.RLock()
+	defer r.RUnlock()
+
+	longest := time.Duration(0)
+
+	for _, s := range r.strategies {
+		if s.discount == nil {
+			continue
+		}
+
+		horizon, has := s.discount.Horizon()
+		if !has {
+			return 0, false
+		}
+
+		if horizon > longest {
+			longest = horizon
+		}
+	}
+
+	return longest, true
+}
+
+// Validate checks that the strategy is registered and accepts the parameters
+func (r *Registry) Validate(name string, params Parameters) error {
+	var err error
+
+	if r.IsContextual(name) {
+		_, err = r.NewContextual(name, 1, params)
+	} else {
+		_, err = r.New(name, []float64{0}, []float64{0}, params)
+	}
+
+	return err
+}
+
+// New creates the algorithm of the named strategy
+func (r *Registry) New(name string, counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+	r.RLock()
+	s, has := r.strategies[name]
+	source := r.source
+	r.RUnlock()
+
+	if !has {
+		return nil, ErrUnknownStrategy
+	}
+	if s.factory == nil {
+		return nil, ErrContextualStrategy
+	}
+
+	a, err := s.factory(counts, rewards, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if source != nil {
+		a.SetSource(source)
+	}
+
+	return a, nil
+}
+
+// NewContextual creates the algorithm of the named contextual strategy
+func (r *Registry) NewContextual(name string, nArms int, params Parameters) (Contextual, error) {
+	r.RLock()
+	s, has := r.strategies[name]
+	source := r.source
+	r.RUnlock()
+
+	if !has {
+		return nil, ErrUnknownStrategy
+	}
+	if s.contextual == nil {
+		return nil, ErrNotContextualStrategy
+	}
+
+	a, err := s.contextual(nArms, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if source != nil {
+		a.SetSource(source)
+	}
+
+	return a, nil
+}
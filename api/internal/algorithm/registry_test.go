@@ -0,0 +1,185 @@
+package algorithm
+
+import (
+	"github.com/koind/banner-rotation/api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewDefaultRegistry(t *testing.T) {
+	registry := NewDefaultRegistry(NewLockedSource(1), config.DefaultStrategies())
+
+	assert.Equal(
+		t,
+		[]string{
+			StrategyAnnealingEpsilonGreedy,
+			StrategyAnnealingSoftmax,
+			StrategyDiscountedUCB,
+			StrategyEpsilonGreedy,
+			StrategyEXP3,
+			StrategyFixedSplit,
+			StrategyKLUCB,
+			StrategyLinUCB,
+			StrategySlidingWindowUCB,
+			StrategySoftmax,
+			StrategyThompson,
+			StrategyUCB1,
+			StrategyUCB1Tuned,
+		},
+		registry.Names(),
+	)
+
+	for _, name := range registry.Names() {
+		if registry.IsContextual(name) {
+			continue
+		}
+
+		a, err := registry.New(name, []float64{1, 2}, []float64{0, 1}, nil)
+		assert.Nil(t, err, name)
+		assert.NotNil(t, a, name)
+	}
+}
+
+func TestRegistry_NewContextual(t *testing.T) {
+	registry := NewDefaultRegistry(NewLockedSource(1), config.DefaultStrategies())
+
+	assert.True(t, registry.IsContextual(StrategyLinUCB))
+	assert.False(t, registry.IsContextual(StrategyUCB1))
+
+	a, err := registry.NewContextual(StrategyLinUCB, 3, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, config.DefaultStrategies().LinUCBDimension, a.Dimension())
+
+	_, err = registry.NewContextual(StrategyUCB1, 3, nil)
+	assert.Equal(t, ErrNotContextualStrategy, err)
+
+	_, err = registry.NewContextual("unknown", 3, nil)
+	assert.Equal(t, ErrUnknownStrategy, err)
+
+	_, err = registry.New(StrategyLinUCB, []float64{1}, []float64{0}, nil)
+	assert.Equal(t, ErrContextualStrategy, err)
+}
+
+func TestRegistry_New(t *testing.T) {
+	registry := NewRegistry()
+
+	_, err := registry.New(StrategyUCB1, nil, nil, nil)
+	assert.Equal(t, ErrUnknownStrategy, err)
+	assert.False(t, registry.Has(StrategyUCB1))
+
+	registry.Register(StrategyUCB1, func(counts []float64, rewards []float64, params Parameters) (Algorithm, error) {
+		return NewUCB1(roundCounts(counts), rewards)
+	})
+	assert.True(t, registry.Has(StrategyUCB1))
+
+	a, err := registry.New(StrategyUCB1, []float64{1, 0}, []float64{1, 0}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, a.SelectArm())
+
+	_, err = registry.New(StrategyUCB1, []float64{1, 0}, []float64{1}, nil)
+	assert.Equal(t, ErrInvalidLength, err)
+}
+
+func TestNewDefaultRegistryUCB1(t *testing.T) {
+	registry := NewDefaultRegistry(NewLockedSource(1), config.DefaultStrategies())
+
+	a, err := registry.New(StrategyUCB1, []float64{1000, 10}, []float64{100, 5}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{0.1, 0.5}, a.(*UCB1).Rewards, "UCB1 should keep the average rewards")
+	assert.Equal(t, 1, a.SelectArm(), "arm with the higher click rate and fewer views should be explored")
+
+	a.Update(1, 1)
+	assert.InDelta(t, 6.0/11, a.(*UCB1).Rewards[1], 1e-9, "update should keep the average reward")
+}
+
+func TestNewDefaultRegistryOptions(t *testing.T) {
+	options := config.DefaultStrategies()
+	options.Epsilon = 0.3
+
+	registry := NewDefaultRegistry(NewLockedSource(1), options)
+
+	a, err := registry.New(StrategyEpsilonGreedy, []float64{1}, []float64{0}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.3, a.(*EpsilonGreedy).Epsilon, "epsilon should be taken from the options")
+
+	options.Temperature = 0.5
+	registry = NewDefaultRegistry(NewLockedSource(1), options)
+
+	a, err = registry.New(StrategySoftmax, []float64{1}, []float64{0}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, a.(*Softmax).Temperature, "temperature should be taken from the options")
+
+	options.EpsilonDecay = "unknown"
+	registry = NewDefaultRegistry(NewLockedSource(1), options)
+
+	_, err = registry.New(StrategyAnnealingEpsilonGreedy, []float64{1}, []float64{0}, nil)
+	assert.Equal(t, ErrInvalidSchedule, err)
+}
+
+func TestRegistry_NewWithParameters(t *testing.T) {
+	registry := NewDefaultRegistry(NewLockedSource(1), config.DefaultStrategies())
+
+	a, err := registry.New(StrategyEXP3, []float64{1}, []float64{0}, Parameters{ParameterGamma: 0.5})
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, a.(*EXP3).Gamma, "gamma should be taken from the parameters")
+
+	a, err = registry.New(StrategyEXP3, []float64{1}, []float64{0}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, config.DefaultStrategies().EXP3Gamma, a.(*EXP3).Gamma, "gamma should fall back to the options")
+
+	a, err = registry.New(StrategyEpsilonGreedy, []float64{1}, []float64{0}, Parameters{ParameterEpsilon: 0.7})
+	assert.Nil(t, err)
+	assert.Equal(t, 0.7, a.(*EpsilonGreedy).Epsilon, "epsilon should be taken from the parameters")
+
+	assert.Nil(t, registry.Validate(StrategyEXP3, Parameters{ParameterGamma: 1}))
+	assert.Equal(t, ErrInvalidGamma, registry.Validate(StrategyEXP3, Parameters{ParameterGamma: 2}))
+	assert.Equal(t, ErrInvalidAlpha, registry.Validate(StrategyLinUCB, Parameters{ParameterAlpha: -1}))
+	assert.Equal(t, ErrUnknownStrategy, registry.Validate("unknown", nil))
+}
+
+func TestRegistry_Discount(t *testing.T) {
+	options := config.DefaultStrategies()
+	options.SlidingWindow = 24
+	options.HalfLife = 12
+
+	registry := NewDefaultRegistry(NewLockedSource(1), options)
+
+	assert.Nil(t, registry.Discount(StrategyUCB1), "stationary strategy should keep all the observations")
+	assert.Nil(t, registry.Discount("unknown"))
+
+	slidingWindow := registry.Discount(StrategySlidingWindowUCB)
+	assert.Equal(t, 1.0, slidingWindow(23*time.Hour))
+	assert.Equal(t, 0.0, slidingWindow(25*time.Hour))
+
+	halfLife := registry.Discount(StrategyDiscountedUCB)
+	assert.InDelta(t, 0.5, halfLife(12*time.Hour), 1e-9)
+}
+
+func TestRegistry_IsWeighted(t *testing.T) {
+	registry := NewDefaultRegistry(NewLockedSource(1), config.DefaultStrategies())
+
+	assert.True(t, registry.IsWeighted(StrategyEXP3), "exp3 should learn from the estimated rewards")
+	assert.False(t, registry.IsWeighted(StrategyUCB1))
+	assert.False(t, registry.IsWeighted("unknown"))
+}
+
+func TestRegistry_Horizon(t *testing.T) {
+	options := config.DefaultStrategies()
+	options.SlidingWindow = 24
+	options.HalfLife = 12
+
+	horizon, has := NewDefaultRegistry(NewLockedSource(1), options).Horizon()
+	assert.True(t, has)
+	assert.InDelta(t, 12*math.Log2(1/ForgottenWeight), horizon.Hours(), 0.1, "half-life should forget later than the window")
+
+	options.HalfLife = 0
+
+	_, has = NewDefaultRegistry(NewLockedSource(1), options).Horizon()
+	assert.False(t, has, "empty half-life should never forget the observations")
+
+	horizon, has = NewRegistry().Horizon()
+	assert.True(t, has)
+	assert.Equal(t, time.Duration(0), horizon)
+}
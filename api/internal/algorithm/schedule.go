@@ -0,0 +1,58 @@
+package algorithm
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// The parameter stays at its initial value
+	ScheduleConstant = "constant"
+
+	// The parameter decays as initial / (1 + rate * t)
+	ScheduleInverse = "inverse"
+
+	// The parameter decays as initial * exp(-rate * t)
+	ScheduleExponential = "exponential"
+)
+
+var (
+	ErrInvalidSchedule = errors.New("unknown schedule or negative decay rate")
+)
+
+// Annealing schedule of an exploration parameter,
+// the parameter decays with the total number of pulls and never falls below the minimum
+type Schedule struct {
+	Kind    string
+	Initial float64
+	Min     float64
+	Rate    float64
+}
+
+// Validate checks the kind and the rate of the schedule
+func (s Schedule) Validate() error {
+	if s.Rate < 0 || s.Min < 0 {
+		return ErrInvalidSchedule
+	}
+
+	switch s.Kind {
+	case "", ScheduleConstant, ScheduleInverse, ScheduleExponential:
+		return nil
+	default:
+		return ErrInvalidSchedule
+	}
+}
+
+// Value returns the value of the parameter after t pulls
+func (s Schedule) Value(t int) float64 {
+	value := s.Initial
+
+	switch s.Kind {
+	case ScheduleInverse:
+		value = s.Initial / (1 + s.Rate*float64(t))
+	case ScheduleExponential:
+		value = s.Initial * math.Exp(-s.Rate*float64(t))
+	}
+
+	return math.Max(value, s.Min)
+}
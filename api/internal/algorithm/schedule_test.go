@@ -0,0 +1,34 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSchedule_Value(t *testing.T) {
+	testCases := map[string]struct {
+		schedule Schedule
+		t        int
+		expected float64
+	}{
+		"constant":             {Schedule{Kind: ScheduleConstant, Initial: 0.2, Rate: 1}, 100, 0.2},
+		"empty kind":           {Schedule{Initial: 0.2, Rate: 1}, 100, 0.2},
+		"inverse at start":     {Schedule{Kind: ScheduleInverse, Initial: 0.2, Rate: 1}, 0, 0.2},
+		"inverse":              {Schedule{Kind: ScheduleInverse, Initial: 0.2, Rate: 1}, 1, 0.1},
+		"inverse minimum":      {Schedule{Kind: ScheduleInverse, Initial: 0.2, Min: 0.05, Rate: 1}, 100, 0.05},
+		"exponential at start": {Schedule{Kind: ScheduleExponential, Initial: 0.2, Rate: 1}, 0, 0.2},
+		"exponential minimum":  {Schedule{Kind: ScheduleExponential, Initial: 0.2, Min: 0.01, Rate: 1}, 10, 0.01},
+	}
+
+	for name, testCase := range testCases {
+		assert.InDelta(t, testCase.expected, testCase.schedule.Value(testCase.t), 1e-9, name)
+	}
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	assert.Nil(t, Schedule{Kind: ScheduleInverse, Rate: 1}.Validate())
+	assert.Nil(t, Schedule{}.Validate())
+	assert.Equal(t, ErrInvalidSchedule, Schedule{Kind: "linear"}.Validate())
+	assert.Equal(t, ErrInvalidSchedule, Schedule{Kind: ScheduleInverse, Rate: -1}.Validate())
+	assert.Equal(t, ErrInvalidSchedule, Schedule{Kind: ScheduleInverse, Min: -1}.Validate())
+}
@@ -0,0 +1,138 @@
+package algorithm
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+var (
+	ErrInvalidTemperature = errors.New("temperature must be greater than zero")
+)
+
+// Softmax (Boltzmann exploration) algorithm
+type Softmax struct {
+	Temperature float64
+	Counts      []int
+	Rewards     []float64
+	randomized
+}
+
+// NewSoftmax returns a pointer to the Softmax struct,
+// rewards hold the accumulated reward (number of clicks) of every arm
+func NewSoftmax(
+	temperature float64,
+	counts []int,
+	rewards []float64,
+	source rand.Source,
+) (*Softmax, error) {
+	if temperature <= 0 {
+		return nil, ErrInvalidTemperature
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &Softmax{
+		Temperature: temperature,
+		Counts:      counts,
+		Rewards:     rewards,
+		randomized:  randomized{rand: rand.New(source)},
+	}, nil
+}
+
+// Reset will set the counts and rewards with the provided number of arms
+func (s *Softmax) Reset(nArms int) error {
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+
+	s.Counts = make([]int, nArms)
+	s.Rewards = make([]float64, nArms)
+
+	return nil
+}
+
+// SelectArm chooses an arm with the probability proportional to exp(reward/temperature)
+func (s *Softmax) SelectArm() int {
+	return categorical(s.rand, s.Probabilities())
+}
+
+// Probabilities returns the probability of selecting each arm
+func (s *Softmax) Probabilities() []float64 {
+	values := make([]float64, len(s.Counts))
+	for i := range s.Counts {
+		values[i] = mean(s.Rewards[i], s.Counts[i]) / s.Temperature
+	}
+
+	// subtracting the max value keeps exp from overflowing
+	_, maxValue := max(values)
+
+	total := 0.0
+	for i, v := range values {
+		values[i] = math.Exp(v - maxValue)
+		total += values[i]
+	}
+
+	for i := range values {
+		values[i] = values[i] / total
+	}
+
+	return values
+}
+
+// Update will update an arm with some reward value
+func (s *Softmax) Update(chosenArm int, reward float64) error {
+	if chosenArm < 0 || chosenArm >= len(s.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	s.Counts[chosenArm]++
+	s.Rewards[chosenArm] += reward
+
+	return nil
+}
+
+// Annealing softmax algorithm, the temperature cools down as the arms are pulled
+type AnnealingSoftmax struct {
+	Softmax
+	Schedule Schedule
+}
+
+// NewAnnealingSoftmax returns a pointer to the AnnealingSoftmax struct
+func NewAnnealingSoftmax(
+	schedule Schedule,
+	counts []int,
+	rewards []float64,
+	source rand.Source,
+) (*AnnealingSoftmax, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	// a decaying temperature needs a floor, otherwise it reaches zero
+	decays := schedule.Kind == ScheduleInverse || schedule.Kind == ScheduleExponential
+	if decays && schedule.Min <= 0 {
+		return nil, ErrInvalidTemperature
+	}
+
+	softmax, err := NewSoftmax(schedule.Initial, counts, rewards, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnnealingSoftmax{
+		Softmax:  *softmax,
+		Schedule: schedule,
+	}, nil
+}
+
+// SelectArm cools down the temperature by the total number of pulls and chooses an arm like softmax
+func (a *AnnealingSoftmax) SelectArm() int {
+	a.Temperature = a.Schedule.Value(total(a.Counts))
+
+	return a.Softmax.SelectArm()
+}
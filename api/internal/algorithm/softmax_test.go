@@ -0,0 +1,105 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestNewSoftmax(t *testing.T) {
+	testCases := map[string]struct {
+		temperature float64
+		counts      []int
+		rewards     []float64
+		err         error
+	}{
+		"nil value":            {0.1, nil, nil, nil},
+		"correct value":        {0.1, make([]int, 3), make([]float64, 3), nil},
+		"invalid length":       {0.1, make([]int, 3), make([]float64, 5), ErrInvalidLength},
+		"zero temperature":     {0, make([]int, 3), make([]float64, 3), ErrInvalidTemperature},
+		"negative temperature": {-1, make([]int, 3), make([]float64, 3), ErrInvalidTemperature},
+	}
+
+	for _, testCase := range testCases {
+		softmax, err := NewSoftmax(testCase.temperature, testCase.counts, testCase.rewards, rand.NewSource(1))
+
+		if testCase.err != nil {
+			assert.Equal(t, testCase.err, err, "errors should match")
+		} else {
+			assert.Nil(t, err, "errors should be nil")
+			assert.Equal(t, testCase.temperature, softmax.Temperature, "temperature should be equal")
+			assert.Equal(t, testCase.counts, softmax.Counts, "counts should be equal")
+			assert.Equal(t, testCase.rewards, softmax.Rewards, "rewards should be equal")
+		}
+	}
+}
+
+func TestSoftmax_Probabilities(t *testing.T) {
+	equal, _ := NewSoftmax(0.1, []int{10, 10}, []float64{1, 1}, rand.NewSource(1))
+	assert.Equal(t, []float64{0.5, 0.5}, equal.Probabilities(), "equal rewards should split evenly")
+
+	softmax, _ := NewSoftmax(0.1, []int{10, 10}, []float64{1, 2}, rand.NewSource(1))
+	probabilities := softmax.Probabilities()
+	assert.InDelta(t, 1, probabilities[0]+probabilities[1], 1e-9, "probabilities should sum to one")
+	assert.True(t, probabilities[1] > probabilities[0], "better arm should be more probable")
+
+	hot, _ := NewSoftmax(100, []int{10, 10}, []float64{1, 2}, rand.NewSource(1))
+	assert.InDelta(t, 0.5, hot.Probabilities()[1], 0.01, "high temperature should be close to uniform")
+}
+
+func TestSoftmax_SelectArm(t *testing.T) {
+	softmax, _ := NewSoftmax(0.01, []int{100, 100, 100}, []float64{1, 50, 2}, rand.NewSource(1))
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, 1, softmax.SelectArm(), "low temperature should exploit the best arm")
+	}
+}
+
+func TestSoftmax_Update(t *testing.T) {
+	softmax, _ := NewSoftmax(0.1, nil, nil, rand.NewSource(1))
+	softmax.Reset(2)
+
+	assert.Equal(t, ErrArmsIndexOutOfRange, softmax.Update(-1, 1))
+	assert.Equal(t, ErrInvalidReward, softmax.Update(0, -1))
+	assert.Nil(t, softmax.Update(0, 1))
+	assert.Equal(t, []int{1, 0}, softmax.Counts)
+	assert.Equal(t, []float64{1, 0}, softmax.Rewards)
+}
+
+func TestNewAnnealingSoftmax(t *testing.T) {
+	testCases := map[string]struct {
+		schedule Schedule
+		err      error
+	}{
+		"inverse schedule":     {Schedule{Kind: ScheduleInverse, Initial: 1, Min: 0.01, Rate: 0.1}, nil},
+		"constant schedule":    {Schedule{Kind: ScheduleConstant, Initial: 1}, nil},
+		"unknown schedule":     {Schedule{Kind: "unknown", Initial: 1}, ErrInvalidSchedule},
+		"zero temperature":     {Schedule{Kind: ScheduleConstant}, ErrInvalidTemperature},
+		"no minimum for decay": {Schedule{Kind: ScheduleExponential, Initial: 1, Rate: 0.1}, ErrInvalidTemperature},
+	}
+
+	for name, testCase := range testCases {
+		_, err := NewAnnealingSoftmax(testCase.schedule, []int{0, 0}, []float64{0, 0}, rand.NewSource(1))
+		assert.Equal(t, testCase.err, err, name)
+	}
+}
+
+func TestAnnealingSoftmax_SelectArm(t *testing.T) {
+	schedule := Schedule{Kind: ScheduleInverse, Initial: 10, Min: 0.001, Rate: 1}
+	annealing, _ := NewAnnealingSoftmax(schedule, []int{10, 10}, []float64{1, 5}, rand.NewSource(1))
+
+	annealing.SelectArm()
+	assert.InDelta(t, 10.0/21, annealing.Temperature, 1e-9, "temperature should decay with the pulls")
+	assert.True(t, annealing.Probabilities()[0] > 0.25, "hot temperature should split the traffic")
+
+	for i := 0; i < 10000; i++ {
+		annealing.Update(1, 0.5)
+	}
+
+	annealing.SelectArm()
+	assert.Equal(t, 0.001, annealing.Temperature, "temperature should not decay below the minimum")
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, 1, annealing.SelectArm(), "cold temperature should exploit the best arm")
+	}
+}
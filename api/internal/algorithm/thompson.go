@@ -0,0 +1,69 @@
+package algorithm
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Thompson Sampling algorithm with Beta-Bernoulli arms
+type ThompsonSampling struct {
+	Counts  []int
+	Rewards []float64
+	randomized
+}
+
+// NewThompsonSampling returns a pointer to the ThompsonSampling struct,
+// rewards hold the accumulated reward (number of clicks) of every arm
+func NewThompsonSampling(counts []int, rewards []float64, source rand.Source) (*ThompsonSampling, error) {
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &ThompsonSampling{
+		Counts:     counts,
+		Rewards:    rewards,
+		randomized: randomized{rand: rand.New(source)},
+	}, nil
+}
+
+// Reset will set the counts and rewards with the provided number of arms
+func (t *ThompsonSampling) Reset(nArms int) error {
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+
+	t.Counts = make([]int, nArms)
+	t.Rewards = make([]float64, nArms)
+
+	return nil
+}
+
+// SelectArm draws a sample from the beta posterior of every arm
+// and chooses the arm with the greatest sample
+func (t *ThompsonSampling) SelectArm() int {
+	samples := make([]float64, len(t.Counts))
+
+	for i := range t.Counts {
+		successes := t.Rewards[i]
+		failures := math.Max(float64(t.Counts[i])-successes, 0)
+
+		samples[i] = betaSample(t.rand, 1+successes, 1+failures)
+	}
+
+	return t.argmax(samples)
+}
+
+// Update will update an arm with some reward value
+func (t *ThompsonSampling) Update(chosenArm int, reward float64) error {
+	if chosenArm < 0 || chosenArm >= len(t.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	t.Counts[chosenArm]++
+	t.Rewards[chosenArm] += reward
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestNewThompsonSampling(t *testing.T) {
+	testCases := map[string]struct {
+		counts  []int
+		rewards []float64
+		err     error
+	}{
+		"nil value":           {nil, nil, nil},
+		"correct value":       {make([]int, 3), make([]float64, 3), nil},
+		"counts is nil":       {nil, make([]float64, 3), ErrInvalidLength},
+		"in counts len less":  {make([]int, 3), make([]float64, 5), ErrInvalidLength},
+		"in rewards len less": {make([]int, 5), make([]float64, 3), ErrInvalidLength},
+	}
+
+	for _, testCase := range testCases {
+		thompson, err := NewThompsonSampling(testCase.counts, testCase.rewards, rand.NewSource(1))
+
+		if testCase.err != nil {
+			assert.Equal(t, testCase.err, err, "errors should match")
+		} else {
+			assert.Nil(t, err, "errors should be nil")
+			assert.Equal(t, testCase.counts, thompson.Counts, "counts should be equal")
+			assert.Equal(t, testCase.rewards, thompson.Rewards, "rewards should be equal")
+		}
+	}
+}
+
+func TestThompsonSampling_Reset(t *testing.T) {
+	testCases := []struct {
+		arms int
+		err  error
+	}{
+		{-1, ErrInvalidArms},
+		{0, ErrInvalidArms},
+		{1, nil},
+		{3, nil},
+	}
+
+	for _, testCase := range testCases {
+		thompson, _ := NewThompsonSampling(nil, nil, rand.NewSource(1))
+		err := thompson.Reset(testCase.arms)
+
+		if testCase.err != nil {
+			assert.Equal(t, testCase.err, err, "should throw error for invalid arms length")
+		} else {
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.arms, len(thompson.Counts), "counts should be of equal length with arm")
+			assert.Equal(t, testCase.arms, len(thompson.Rewards), "rewards should be of equal length with arm")
+		}
+	}
+}
+
+func TestThompsonSampling_Update(t *testing.T) {
+	thompson, err := NewThompsonSampling(nil, nil, rand.NewSource(1))
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		arms      int
+		chosenArm int
+		reward    float64
+		err       error
+	}{
+		{1, 0, 0.0, nil},
+		{1, -1, 0.0, ErrArmsIndexOutOfRange},
+		{1, 1, 0.0, ErrArmsIndexOutOfRange},
+		{3, 1, 1.0, nil},
+		{3, 1, -1.0, ErrInvalidReward},
+	}
+
+	for _, testCase := range testCases {
+		thompson.Reset(testCase.arms)
+		err := thompson.Update(testCase.chosenArm, testCase.reward)
+		if testCase.err != nil {
+			assert.Equal(t, testCase.err, err, "should throw error for invalid params")
+		} else {
+			assert.Nil(t, err)
+			assert.Equal(t, 1, thompson.Counts[testCase.chosenArm])
+			assert.Equal(t, testCase.reward, thompson.Rewards[testCase.chosenArm])
+		}
+	}
+}
+
+func TestThompsonSampling_SelectArm(t *testing.T) {
+	counts := []int{1000, 1000, 1000}
+	rewards := []float64{10, 300, 20}
+
+	thompson, _ := NewThompsonSampling(counts, rewards, rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, 1, thompson.SelectArm(), "should exploit the arm with the best ctr")
+	}
+}
+
+func TestThompsonSampling_SelectArmIsReproducible(t *testing.T) {
+	counts := []int{3, 2, 5, 0}
+	rewards := []float64{1, 1, 2, 0}
+
+	first, _ := NewThompsonSampling(counts, rewards, rand.NewSource(42))
+	second, _ := NewThompsonSampling(counts, rewards, rand.NewSource(42))
+
+	for i := 0; i < 50; i++ {
+		assert.Equal(t, first.SelectArm(), second.SelectArm(), "arms should match for the same seed")
+	}
+}
+
+func TestBetaSample(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	testCases := []struct {
+		alpha float64
+		beta  float64
+	}{
+		{1, 1},
+		{0.5, 0.5},
+		{2, 8},
+		{50, 10},
+	}
+
+	for _, testCase := range testCases {
+		n := 20000
+		sum := 0.0
+
+		for i := 0; i < n; i++ {
+			v := betaSample(r, testCase.alpha, testCase.beta)
+			assert.True(t, v >= 0 && v <= 1, "sample should be in [0, 1]")
+			sum += v
+		}
+
+		expected := testCase.alpha / (testCase.alpha + testCase.beta)
+		assert.InDelta(t, expected, sum/float64(n), 0.01, "sample mean should match the distribution mean")
+	}
+}
@@ -3,6 +3,7 @@ package algorithm
 import (
 	"errors"
 	"math"
+	"math/rand"
 )
 
 var (
@@ -12,17 +13,27 @@ var (
 	ErrInvalidReward       = errors.New("reward must be greater than zero")
 )
 
-// Algorithm interface
+// Algorithm interface, the random source breaks the ties between the arms
 type Algorithm interface {
 	Reset(int) error
 	SelectArm() int
-	Update(int, float64)
+	Update(int, float64) error
+	SetSource(rand.Source)
+}
+
+// Probabilistic algorithm selects the arms at random with the known probabilities
+type Probabilistic interface {
+	Algorithm
+
+	// Probabilities returns the probability of selecting each arm
+	Probabilities() []float64
 }
 
 // UCB1 algorithm
 type UCB1 struct {
 	Counts  []int
 	Rewards []float64
+	randomized
 }
 
 // NewUCB1 returns a pointer to the UCB1 struct
@@ -49,13 +60,11 @@ func (u *UCB1) Reset(nArms int) error {
 	return nil
 }
 
-// SelectArm chooses an arm that exploits if the value is more than the epsilon
-// threshold, and explore if the value is less than epsilon
+// SelectArm chooses an arm with the greatest upper confidence bound,
+// every arm that has never been pulled is chosen first, the ties are broken at random
 func (b *UCB1) SelectArm() (index int) {
-	for i, v := range b.Counts {
-		if v == 0 {
-			return i
-		}
+	if i := b.unpulled(floatCounts(b.Counts)); i >= 0 {
+		return i
 	}
 
 	totalCounts := 0
@@ -69,9 +78,7 @@ func (b *UCB1) SelectArm() (index int) {
 		ucbValues = append(ucbValues, b.Rewards[i]+bonus)
 	}
 
-	maxIndex, _ := max(ucbValues)
-
-	return maxIndex
+	return b.argmax(ucbValues)
 }
 
 // Update will update an arm with some reward value
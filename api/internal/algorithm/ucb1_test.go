@@ -2,6 +2,7 @@ package algorithm
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math/rand"
 	"testing"
 )
 
@@ -155,3 +156,60 @@ func TestUCB1_SelectArm2(t *testing.T) {
 	chosenArm := ucb1.SelectArm()
 	assert.Equal(t, expectedArm, chosenArm)
 }
+
+func TestUCB1_SelectArmBreaksTies(t *testing.T) {
+	ucb1, _ := NewUCB1([]int{0, 0, 0, 5}, []float64{0, 0, 0, 1})
+	ucb1.SetSource(rand.NewSource(1))
+
+	chosen := make(map[int]int)
+	for i := 0; i < 300; i++ {
+		chosen[ucb1.SelectArm()]++
+	}
+
+	assert.Len(t, chosen, 3, "every unpulled arm should be chosen")
+	assert.Equal(t, 0, chosen[3], "pulled arm should wait for the unpulled arms")
+
+	tied, _ := NewUCB1([]int{5, 5}, []float64{0.5, 0.5})
+	tied.SetSource(rand.NewSource(1))
+
+	chosen = make(map[int]int)
+	for i := 0; i < 300; i++ {
+		chosen[tied.SelectArm()]++
+	}
+
+	assert.True(t, chosen[0] > 100 && chosen[1] > 100, "equal arms should split the traffic")
+}
+
+func TestUCB1_SelectArmReproducible(t *testing.T) {
+	sequence := func(seed int64) []int {
+		ucb1, _ := NewUCB1(make([]int, 10), make([]float64, 10))
+		ucb1.SetSource(rand.NewSource(seed))
+
+		arms := make([]int, 0, 10)
+		for i := 0; i < 10; i++ {
+			arm := ucb1.SelectArm()
+			ucb1.Update(arm, 0)
+			arms = append(arms, arm)
+		}
+
+		return arms
+	}
+
+	assert.Equal(t, sequence(7), sequence(7), "the same seed should select the same arms")
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, sequence(7), "every arm should be pulled once")
+}
+
+func TestArgmax(t *testing.T) {
+	var deterministic randomized
+	assert.Equal(t, 1, deterministic.argmax([]float64{1, 3, 3}), "without a source the first max should win")
+	assert.Equal(t, 0, deterministic.argmax(nil))
+	assert.Equal(t, -1, deterministic.unpulled([]float64{1, 2}))
+
+	r := randomized{rand: rand.New(rand.NewSource(1))}
+	chosen := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		chosen[r.argmax([]float64{1, 3, 0, 3})] = true
+	}
+
+	assert.Equal(t, map[int]bool{1: true, 3: true}, chosen, "only the max values should be chosen")
+}
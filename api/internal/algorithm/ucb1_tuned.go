@@ -0,0 +1,75 @@
+package algorithm
+
+import (
+	"math"
+)
+
+// UCB1-Tuned algorithm, scales the exploration bonus by the variance of the arm
+type UCB1Tuned struct {
+	Counts  []int
+	Rewards []float64
+	randomized
+}
+
+// NewUCB1Tuned returns a pointer to the UCB1Tuned struct,
+// rewards hold the accumulated reward (number of clicks) of every arm
+func NewUCB1Tuned(counts []int, rewards []float64) (*UCB1Tuned, error) {
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &UCB1Tuned{
+		Counts:  counts,
+		Rewards: rewards,
+	}, nil
+}
+
+// Reset will set the counts and rewards with the provided number of arms
+func (u *UCB1Tuned) Reset(nArms int) error {
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+
+	u.Counts = make([]int, nArms)
+	u.Rewards = make([]float64, nArms)
+
+	return nil
+}
+
+// SelectArm chooses an arm with the greatest upper confidence bound,
+// every arm that has never been pulled is chosen first
+func (u *UCB1Tuned) SelectArm() int {
+	if i := u.unpulled(floatCounts(u.Counts)); i >= 0 {
+		return i
+	}
+
+	logTotal := math.Log(float64(total(u.Counts)))
+
+	ucbValues := make([]float64, len(u.Counts))
+	for i, count := range u.Counts {
+		n := float64(count)
+		p := math.Min(mean(u.Rewards[i], count), 1)
+
+		// the variance of a bernoulli arm plus its own confidence bound
+		variance := p*(1-p) + math.Sqrt(2*logTotal/n)
+
+		ucbValues[i] = p + math.Sqrt(logTotal/n*math.Min(0.25, variance))
+	}
+
+	return u.argmax(ucbValues)
+}
+
+// Update will update an arm with some reward value
+func (u *UCB1Tuned) Update(chosenArm int, reward float64) error {
+	if chosenArm < 0 || chosenArm >= len(u.Rewards) {
+		return ErrArmsIndexOutOfRange
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	u.Counts[chosenArm]++
+	u.Rewards[chosenArm] += reward
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package algorithm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewUCB1Tuned(t *testing.T) {
+	_, err := NewUCB1Tuned(make([]int, 3), make([]float64, 2))
+	assert.Equal(t, ErrInvalidLength, err)
+
+	ucb1Tuned, err := NewUCB1Tuned(make([]int, 3), make([]float64, 3))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(ucb1Tuned.Counts))
+
+	assert.Equal(t, ErrInvalidArms, ucb1Tuned.Reset(0))
+	assert.Nil(t, ucb1Tuned.Reset(2))
+	assert.Equal(t, []int{0, 0}, ucb1Tuned.Counts)
+}
+
+func TestUCB1Tuned_SelectArm(t *testing.T) {
+	ucb1Tuned, _ := NewUCB1Tuned([]int{10, 0, 10}, []float64{1, 0, 2})
+	assert.Equal(t, 1, ucb1Tuned.SelectArm(), "arm without pulls should be chosen first")
+
+	// ctr of 2% against a banner without clicks after 5000 views
+	ucb1Tuned, _ = NewUCB1Tuned([]int{100000, 5000}, []float64{2000, 0})
+	assert.Equal(t, 0, ucb1Tuned.SelectArm(), "should exploit the arm with the best ctr")
+
+	ucb1, _ := NewUCB1([]int{100000, 5000}, []float64{0.02, 0})
+	assert.Equal(t, 1, ucb1.SelectArm(), "plain bonus still explores the worse arm")
+}
+
+func TestUCB1Tuned_Update(t *testing.T) {
+	ucb1Tuned, _ := NewUCB1Tuned(nil, nil)
+	ucb1Tuned.Reset(2)
+
+	assert.Equal(t, ErrArmsIndexOutOfRange, ucb1Tuned.Update(2, 1))
+	assert.Equal(t, ErrInvalidReward, ucb1Tuned.Update(0, -1))
+	assert.Nil(t, ucb1Tuned.Update(0, 1))
+	assert.Equal(t, []int{1, 0}, ucb1Tuned.Counts)
+	assert.Equal(t, []float64{1, 0}, ucb1Tuned.Rewards)
+}
@@ -0,0 +1,62 @@
+package algorithm
+
+import (
+	"math"
+)
+
+// Returns the average reward of the arm
+func mean(reward float64, count int) float64 {
+	if count <= 0 {
+		return 0
+	}
+
+	return reward / float64(count)
+}
+
+// Returns the total number of pulls of all the arms
+func total(counts []int) int {
+	sum := 0
+	for _, v := range counts {
+		sum += v
+	}
+
+	return sum
+}
+
+// Converts the counts to float
+func floatCounts(counts []int) []float64 {
+	result := make([]float64, len(counts))
+	for i, v := range counts {
+		result[i] = float64(v)
+	}
+
+	return result
+}
+
+// Rounds the counts for the algorithms that work with whole numbers of pulls
+func roundCounts(counts []float64) []int {
+	if counts == nil {
+		return nil
+	}
+
+	rounded := make([]int, len(counts))
+	for i, v := range counts {
+		rounded[i] = int(math.Round(v))
+	}
+
+	return rounded
+}
+
+// Returns the average rewards of the arms from their total rewards
+func meanRewards(counts []int, rewards []float64) []float64 {
+	if rewards == nil || len(counts) != len(rewards) {
+		return rewards
+	}
+
+	means := make([]float64, len(rewards))
+	for i, reward := range rewards {
+		means[i] = mean(reward, counts[i])
+	}
+
+	return means
+}
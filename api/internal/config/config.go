@@ -16,11 +16,22 @@ type Options struct {
 	GRPCServer GRPCServer
 	HTTPServer HTTPServer
 	RabbitMQ   RabbitMQ
+	Strategies Strategies
+	Cache      Cache
+	Dayparting Dayparting
+	Frequency  Frequency
+	Counters   Counters
 }
 
 // Initializes microservice configurations
 func Init(configPath string) Options {
-	opt := Options{}
+	opt := Options{
+		Strategies: DefaultStrategies(),
+		Cache:      DefaultCache(),
+		Dayparting: Dayparting{Timezone: "UTC"},
+		Frequency:  DefaultFrequency(),
+		Counters:   DefaultCounters(),
+	}
 
 	if _, err := toml.DecodeFile(configPath, &opt); err != nil {
 		log.Fatal("Не удалось загрузить конфиги микросервиса ", err)
@@ -66,3 +77,180 @@ type RabbitMQ struct {
 	ExchangeName string
 	QueueName    string
 }
+
+// Settings of the banner selection strategies
+type Strategies struct {
+	// Seed of the random source of the strategies, zero seeds it with the current time
+	Seed int64
+
+	// Exploration rate of the epsilon-greedy strategies
+	Epsilon float64
+
+	// Decay schedule of the annealing epsilon-greedy strategy: constant, inverse or exponential
+	EpsilonDecay string
+
+	// Decay rate per banner view
+	EpsilonDecayRate float64
+
+	// Epsilon never decays below this value
+	MinEpsilon float64
+
+	// Temperature of the softmax strategies, the lower it is the more the best banner is shown
+	Temperature float64
+
+	// Decay schedule of the annealing softmax strategy: constant, inverse or exponential
+	TemperatureDecay string
+
+	// Decay rate per banner view
+	TemperatureDecayRate float64
+
+	// Temperature never decays below this value, must be greater than zero
+	MinTemperature float64
+
+	// Constant of the log(log(t)) term of the KL-UCB exploration rate
+	KLUCBConstant float64
+
+	// Window of the sliding-window UCB strategy in hours
+	SlidingWindow int
+
+	// Half-life of the observations of the discounted UCB strategy in hours
+	HalfLife int
+
+	// Width of the confidence bound of the LinUCB strategy
+	LinUCBAlpha float64
+
+	// Number of features the request attributes are hashed into
+	LinUCBDimension int
+
+	// Share of uniform exploration of the EXP3 strategy, can be set per slot
+	EXP3Gamma float64
+
+	// Conversion values are divided by it to keep the rewards within [0, 1], zero keeps the values as is
+	MaxConversionValue float64
+
+	// Click model that debiases the views by the position of the banner: position, cascade or empty for none
+	ClickModel string
+
+	// Examination probability of every position of the position-based click model starting from the first one
+	PositionBias []float64
+
+	// Window the minimum and maximum shares of the views of the banners are tracked over in hours
+	ShareWindow int
+}
+
+// Returns the default settings of the strategies
+func DefaultStrategies() Strategies {
+	return Strategies{
+		Epsilon:          0.1,
+		EpsilonDecay:     "inverse",
+		EpsilonDecayRate: 0.001,
+		MinEpsilon:       0.01,
+
+		Temperature:          0.1,
+		TemperatureDecay:     "inverse",
+		TemperatureDecayRate: 0.001,
+		MinTemperature:       0.01,
+
+		SlidingWindow: 72,
+		HalfLife:      24,
+
+		LinUCBAlpha:     1,
+		LinUCBDimension: 32,
+
+		EXP3Gamma: 0.1,
+
+		PositionBias: []float64{1, 0.7, 0.5, 0.4, 0.3},
+
+		ShareWindow: 24,
+	}
+}
+
+// Settings of the in-process selection cache
+type Cache struct {
+	// Keeps the selection state in memory and writes the statistics and counters behind
+	Enabled bool
+
+	// Interval between the flushes to the database in milliseconds
+	FlushInterval int
+
+	// Number of the pending statistics or counters that triggers the flush before the interval
+	FlushSize int
+
+	// Interval the cached counters, rotations and slots are loaded again from the database after in milliseconds
+	RefreshInterval int
+}
+
+// Returns the default settings of the cache
+func DefaultCache() Cache {
+	return Cache{
+		FlushInterval:   1000,
+		FlushSize:       1000,
+		RefreshInterval: 60000,
+	}
+}
+
+// Settings of the dayparting of the banners
+type Dayparting struct {
+	// Timezone of the dayparting rules of the rotations without their own timezone
+	Timezone string
+}
+
+const (
+	// Impressions of the visitors are kept in postgres and shared by the instances of the service
+	ImpressionsStorePostgres = "postgres"
+
+	// Impressions of the visitors are kept in the memory of the instance of the service
+	ImpressionsStoreMemory = "memory"
+)
+
+// Settings of the frequency capping of the banners per visitor
+type Frequency struct {
+	// Number of times the banner is shown to the visitor over the window, zero does not cap the visitors
+	Cap int
+
+	// Window the impressions of the banners to the visitor are capped over in hours
+	Window int
+
+	// Interval between the removals of the expired impressions in minutes
+	ExpireInterval int
+
+	// Store of the impressions of the visitors, postgres or memory
+	Store string
+}
+
+// Returns the store of the impressions, when it is not set the impressions are kept in memory
+// with the cache enabled and in postgres otherwise
+func (f Frequency) GetStore(cacheEnabled bool) string {
+	if f.Store != "" {
+		return f.Store
+	}
+
+	if cacheEnabled {
+		return ImpressionsStoreMemory
+	}
+
+	return ImpressionsStorePostgres
+}
+
+// Returns the default settings of the frequency capping
+func DefaultFrequency() Frequency {
+	return Frequency{
+		Cap:            3,
+		Window:         24,
+		ExpireInterval: 60,
+	}
+}
+
+// Settings of the hourly counters of the banners
+type Counters struct {
+	// Interval between the removals of the hourly counters older than the strategies weigh in minutes,
+	// the totals of the counters are kept
+	PruneInterval int
+}
+
+// Returns the default settings of the counters
+func DefaultCounters() Counters {
+	return Counters{
+		PruneInterval: 60,
+	}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	ErrInvalidAttributes = errors.New("attributes must be a json object")
+)
+
+// Attributes of the request, such as device, hour or referrer
+type Attributes map[string]string
+
+// Value returns the attributes encoded in json for the database
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan decodes the attributes from the json stored in the database
+func (a *Attributes) Scan(src interface{}) error {
+	var data []byte
+
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return ErrInvalidAttributes
+	}
+
+	return json.Unmarshal(data, a)
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Period the counters are aggregated over, the discounting strategies weigh the counters by their period
+const CountersPeriod = time.Hour
+
+// The repository interface counters
+type CountersRepositoryInterface interface {
+	// Adds the counters to the stored counters of the same slot, group, banner, period and position
+	// and to the totals of the same slot, group, banner and position atomically
+	Add(ctx context.Context, counters ...Counters) error
+
+	// Find all the counters by slot and group
+	FindAllBySlotIDAndGroupID(ctx context.Context, slotID int, groupID int) ([]*Counters, error)
+
+	// Find the totals of the counters by slot and group, the totals have no period
+	FindTotalsBySlotIDAndGroupID(ctx context.Context, slotID int, groupID int) ([]*Counters, error)
+
+	// Find the totals of the counters of the slot in all the groups
+	FindTotalsBySlotID(ctx context.Context, slotID int) ([]*Counters, error)
+
+	// Find the totals of the counters of the banner in all the slots by group
+	FindTotalsByBannerIDAndGroupID(ctx context.Context, bannerID int, groupID int) ([]*Counters, error)
+
+	// Find all the counters of the slot in all the groups from the period of the time
+	FindAllBySlotIDSince(ctx context.Context, slotID int, since time.Time) ([]*Counters, error)
+
+	// Removes the counters of the periods before the time, the totals are kept
+	RemoveBefore(ctx context.Context, before time.Time) error
+}
+
+// Counters model, the aggregated statistics of the banner in the slot and group over the period
+// at the position the banner was shown at
+type Counters struct {
+	SlotID      int       `json:"slotId" db:"slot_id"`
+	GroupID     int       `json:"groupId" db:"group_id"`
+	BannerID    int       `json:"bannerId" db:"banner_id"`
+	Period      time.Time `json:"period" db:"period"`
+	Position    int       `json:"position" db:"position"`
+	Views       int       `json:"views" db:"views"`
+	Clicks      int       `json:"clicks" db:"clicks"`
+	Conversions int       `json:"conversions" db:"conversions"`
+	Value       float64   `json:"value" db:"value"`
+
+	// Sum of the inverse probabilities the views were selected with, the view of unknown probability counts as one
+	InverseProbability float64 `json:"inverseProbability" db:"inverse_probability"`
+}
+
+// Returns the counters of the statistics
+func NewCounters(statistics Statistics) Counters {
+	counters := Counters{
+		SlotID:   statistics.SlotID,
+		GroupID:  statistics.GroupID,
+		BannerID: statistics.BannerID,
+		Period:   statistics.CreatedAt.UTC().Truncate(CountersPeriod),
+		Position: statistics.Position,
+	}
+
+	switch {
+	case statistics.IsTypeView():
+		counters.Views = 1
+		counters.InverseProbability = 1
+
+		if statistics.Probability > 0 {
+			counters.InverseProbability = 1 / statistics.Probability
+		}
+	case statistics.IsTypeClick():
+		counters.Clicks = 1
+	case statistics.IsTypeConversion():
+		counters.Conversions = 1
+		counters.Value = statistics.Value
+	}
+
+	return counters
+}
+
+// Returns the counters without the period, they are added to the totals of the counters
+func (c Counters) Total() Counters {
+	c.Period = time.Time{}
+
+	return c
+}
+
+// Adds the other counters
+func (c *Counters) Merge(other Counters) {
+	c.Views += other.Views
+	c.Clicks += other.Clicks
+	c.Conversions += other.Conversions
+	c.Value += other.Value
+	c.InverseProbability += other.InverseProbability
+}
+
+// Returns the reward divided by the probabilities of the views, the clicks and conversions are not linked
+// to their views, so the reward is weighed by the average inverse probability of the views of the counters
+func (c *Counters) Estimate(reward float64) float64 {
+	if c.Views <= 0 || c.InverseProbability <= 0 {
+		return reward
+	}
+
+	return reward * c.InverseProbability / float64(c.Views)
+}
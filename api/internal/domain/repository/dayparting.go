@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidDayparting = errors.New("dayparting hours must be within [0, 24] and weekdays within [0, 6]")
+	ErrInvalidRules      = errors.New("dayparting must be a json array")
+
+	// Loaded locations by the name of the timezone
+	locations sync.Map
+)
+
+// Recurring rule of the weekdays and the hours the banner is selected at,
+// the hours from the start to the end, the end before the start wraps the hours over the midnight
+// and the equal start and end take the whole day
+type DaypartRule struct {
+	// Weekdays from Sunday (0) to Saturday (6), empty for every day
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`
+	From     int            `json:"from"`
+	To       int            `json:"to"`
+}
+
+// Checks the hours and the weekdays of the rule
+func (r DaypartRule) Validate() error {
+	if r.From < 0 || r.From > 24 || r.To < 0 || r.To > 24 {
+		return ErrInvalidDayparting
+	}
+
+	for _, weekday := range r.Weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return ErrInvalidDayparting
+		}
+	}
+
+	return nil
+}
+
+// Does the rule match the local time
+func (r DaypartRule) Matches(t time.Time) bool {
+	if len(r.Weekdays) > 0 {
+		matches := false
+		for _, weekday := range r.Weekdays {
+			if weekday == t.Weekday() {
+				matches = true
+			}
+		}
+
+		if !matches {
+			return false
+		}
+	}
+
+	hour := t.Hour()
+
+	switch {
+	case r.From == r.To:
+		return true
+	case r.From < r.To:
+		return hour >= r.From && hour < r.To
+	default:
+		return hour >= r.From || hour < r.To
+	}
+}
+
+// Dayparting of the banner, the banner is selected when any of the rules matches,
+// the banner without rules is selected at any time
+type Dayparting []DaypartRule
+
+// Checks all the rules
+func (d Dayparting) Validate() error {
+	for _, rule := range d {
+		err := rule.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Does any of the rules match the local time
+func (d Dayparting) Matches(t time.Time) bool {
+	if len(d) == 0 {
+		return true
+	}
+
+	for _, rule := range d {
+		if rule.Matches(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Value returns the rules encoded in json for the database
+func (d Dayparting) Value() (driver.Value, error) {
+	if d == nil {
+		return "[]", nil
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan decodes the rules from the json stored in the database
+func (d *Dayparting) Scan(src interface{}) error {
+	var data []byte
+
+	switch v := src.(type) {
+	case nil:
+		*d = nil
+
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return ErrInvalidRules
+	}
+
+	return json.Unmarshal(data, d)
+}
+
+// Returns the location of the timezone, the loaded locations are cached
+func LoadLocation(timezone string) (*time.Location, error) {
+	if location, has := locations.Load(timezone); has {
+		return location.(*time.Location), nil
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	locations.Store(timezone, location)
+
+	return location, nil
+}
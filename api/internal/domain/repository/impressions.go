@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// The repository interface impressions, the impressions are kept till they expire
+type ImpressionsRepositoryInterface interface {
+	// Adds the impressions of the banners to the visitors
+	Add(ctx context.Context, impressions ...Impression) error
+
+	// Find all the impressions of the visitor not expired by the time
+	FindAllByVisitorID(ctx context.Context, visitorID string, now time.Time) ([]*Impression, error)
+
+	// Removes all the impressions expired by the time
+	RemoveExpired(ctx context.Context, now time.Time) error
+}
+
+// Impression model, the banner shown to the visitor that is counted till it expires
+type Impression struct {
+	VisitorID string    `json:"visitorId" db:"visitor_id"`
+	BannerID  int       `json:"bannerId" db:"banner_id"`
+	SlotID    int       `json:"slotId" db:"slot_id"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	ExpiresAt time.Time `json:"expiresAt" db:"expires_at"`
+}
+
+// Is the impression expired by the time
+func (i *Impression) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
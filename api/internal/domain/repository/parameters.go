@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	ErrInvalidParameters = errors.New("parameters must be a json object")
+)
+
+// Parameters of the strategy, such as epsilon or gamma
+type Parameters map[string]float64
+
+// Value returns the parameters encoded in json for the database
+func (p Parameters) Value() (driver.Value, error) {
+	if p == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan decodes the parameters from the json stored in the database
+func (p *Parameters) Scan(src interface{}) error {
+	var data []byte
+
+	switch v := src.(type) {
+	case nil:
+		*p = nil
+
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return ErrInvalidParameters
+	}
+
+	return json.Unmarshal(data, p)
+}
@@ -2,9 +2,20 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+var (
+	ErrUnknownPrior    = errors.New("unknown prior")
+	ErrInvalidPrior    = errors.New("prior views and clicks can't be negative")
+	ErrInvalidSchedule = errors.New("rotation must end after it starts")
+	ErrUnknownStatus   = errors.New("unknown rotation status")
+	ErrInvalidWeight   = errors.New("weight can't be negative")
+	ErrInvalidShare    = errors.New("shares must be within [0, 1] and the minimum share can't exceed the maximum one")
+	ErrInvalidBudget   = errors.New("views and clicks caps can't be negative")
+)
+
 // The repository interface rotation
 type RotationRepositoryInterface interface {
 	// Adds a new banner to the rotation in this slot
@@ -16,25 +27,234 @@ type RotationRepositoryInterface interface {
 	// Find all rotations by slot id
 	FindAllBySlotID(ctx context.Context, slotID int) ([]*Rotation, error)
 
+	// Updates the settings of the rotation of the banner
+	Update(ctx context.Context, rotation Rotation) (*Rotation, error)
+
 	// Removes the banner from the rotation
 	Remove(ctx context.Context, bannerID int) error
 }
 
 // Banner model
 type Banner struct {
-	ID      int
-	GroupID int
-	Views   int
-	Clicks  float64
+	ID          int
+	GroupID     int
+	Views       float64
+	Clicks      float64
+	Conversions float64
 }
 
+const (
+	// The banner is selected
+	RotationStatusActive = "active"
+
+	// The banner is not selected until it is resumed, its statistics are kept
+	RotationStatusPaused = "paused"
+
+	// The banner is not selected anymore, its statistics are kept
+	RotationStatusArchived = "archived"
+)
+
+const (
+	// The banner starts from zero knowledge
+	PriorNone = ""
+
+	// The banner starts from the prior views and clicks
+	PriorFixed = "fixed"
+
+	// The banner inherits the average reward of the other banners of the slot
+	PriorSlot = "slot"
+
+	// The banner inherits the reward of the same banner in the other slots
+	PriorBanner = "banner"
+)
+
 // Rotation model
 type Rotation struct {
-	ID          int       `json:"id" db:"id"`
-	BannerID    int       `json:"bannerId" db:"banner_id"`
-	SlotID      int       `json:"slotId" db:"slot_id"`
-	Description string    `json:"description" db:"description"`
-	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+	ID          int    `json:"id" db:"id"`
+	BannerID    int    `json:"bannerId" db:"banner_id"`
+	SlotID      int    `json:"slotId" db:"slot_id"`
+	Description string `json:"description" db:"description"`
+	Status      string `json:"status,omitempty" db:"status"`
+
+	// Prior pseudo-counts added to the statistics of the banner,
+	// the inherited priors take the prior views as the weight of the inherited reward
+	Prior       string  `json:"prior,omitempty" db:"prior"`
+	PriorViews  float64 `json:"priorViews,omitempty" db:"prior_views"`
+	PriorClicks float64 `json:"priorClicks,omitempty" db:"prior_clicks"`
+
+	// The banner is selected from its start till its end, at any time without them
+	StartsAt *time.Time `json:"startsAt,omitempty" db:"starts_at"`
+	EndsAt   *time.Time `json:"endsAt,omitempty" db:"ends_at"`
+
+	// Hours and weekdays the banner is selected at in the timezone of the rotation
+	Timezone   string     `json:"timezone,omitempty" db:"timezone"`
+	Dayparting Dayparting `json:"dayparting,omitempty" db:"dayparting"`
+
+	// Scale of the reward of the banner
+	Weight float64 `json:"weight,omitempty" db:"weight"`
+
+	// Bounds of the share of the views of the slot the banner gets
+	MinShare float64 `json:"minShare,omitempty" db:"min_share"`
+	MaxShare float64 `json:"maxShare,omitempty" db:"max_share"`
+
+	// Total and daily caps of the views and clicks, the banner is not selected once it reached any of them
+	ViewsCap       int `json:"viewsCap,omitempty" db:"views_cap"`
+	ClicksCap      int `json:"clicksCap,omitempty" db:"clicks_cap"`
+	DailyViewsCap  int `json:"dailyViewsCap,omitempty" db:"daily_views_cap"`
+	DailyClicksCap int `json:"dailyClicksCap,omitempty" db:"daily_clicks_cap"`
+
+	// Spreads the daily caps evenly over the day
+	Pacing bool `json:"pacing,omitempty" db:"pacing"`
+
+	// Groups and attributes of the requests the banner is selected for, nil for all the requests
+	Targeting *Targeting `json:"targeting,omitempty" db:"targeting"`
+
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// Checks the prior of the rotation
+func (r *Rotation) ValidatePrior() error {
+	switch r.Prior {
+	case PriorNone, PriorFixed, PriorSlot, PriorBanner:
+	default:
+		return ErrUnknownPrior
+	}
+
+	if r.PriorViews < 0 || r.PriorClicks < 0 {
+		return ErrInvalidPrior
+	}
+
+	return nil
+}
+
+// Checks the status of the rotation, the rotation without status is active
+func (r *Rotation) ValidateStatus() error {
+	switch r.Status {
+	case "", RotationStatusActive, RotationStatusPaused, RotationStatusArchived:
+		return nil
+	}
+
+	return ErrUnknownStatus
+}
+
+// Checks the weight and the shares of the rotation, zero maximum share does not bound the share
+func (r *Rotation) ValidateShare() error {
+	if r.Weight < 0 {
+		return ErrInvalidWeight
+	}
+
+	if r.MinShare < 0 || r.MinShare > 1 || r.MaxShare < 0 || r.MaxShare > 1 {
+		return ErrInvalidShare
+	}
+
+	if r.MaxShare > 0 && r.MinShare > r.MaxShare {
+		return ErrInvalidShare
+	}
+
+	return nil
+}
+
+// Does the rotation bound its share of the views
+func (r *Rotation) HasShare() bool {
+	return r.MinShare > 0 || r.MaxShare > 0
+}
+
+// Checks the caps of the views and clicks of the rotation, zero cap does not bound them
+func (r *Rotation) ValidateBudget() error {
+	if r.ViewsCap < 0 || r.ClicksCap < 0 || r.DailyViewsCap < 0 || r.DailyClicksCap < 0 {
+		return ErrInvalidBudget
+	}
+
+	return nil
+}
+
+// Does the rotation cap its views or clicks
+func (r *Rotation) HasBudget() bool {
+	return r.ViewsCap > 0 || r.ClicksCap > 0 || r.DailyViewsCap > 0 || r.DailyClicksCap > 0
+}
+
+// Returns the weight of the reward of the banner, the rotation without weight weighs one
+func (r *Rotation) RewardWeight() float64 {
+	if r.Weight <= 0 {
+		return 1
+	}
+
+	return r.Weight
+}
+
+// Is the banner selected, the rotation without status is active
+func (r *Rotation) IsActive() bool {
+	return r.Status == RotationStatusActive || r.Status == ""
+}
+
+// Checks that the rotation ends after it starts
+func (r *Rotation) ValidateSchedule() error {
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return ErrInvalidSchedule
+	}
+
+	return nil
+}
+
+// Checks the dayparting rules and the timezone of the rotation
+func (r *Rotation) ValidateDayparting() error {
+	if r.Timezone != "" {
+		_, err := LoadLocation(r.Timezone)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.Dayparting.Validate()
+}
+
+// Is the banner selected at the time by its dayparting, location is the timezone of the rotation without one
+func (r *Rotation) IsDayparted(t time.Time, location *time.Location) (bool, error) {
+	if len(r.Dayparting) == 0 {
+		return true, nil
+	}
+
+	if r.Timezone != "" {
+		var err error
+
+		location, err = LoadLocation(r.Timezone)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if location == nil {
+		location = time.UTC
+	}
+
+	return r.Dayparting.Matches(t.In(location)), nil
+}
+
+// Checks the targeting of the rotation, the rotation without targeting is selected for any request
+func (r *Rotation) ValidateTargeting() error {
+	if r.Targeting == nil {
+		return nil
+	}
+
+	return r.Targeting.Validate()
+}
+
+// Is the banner selected for the group and the attributes of the request
+func (r *Rotation) IsTargeted(groupID int, attributes Attributes) bool {
+	return r.Targeting == nil || r.Targeting.Matches(groupID, attributes)
+}
+
+// Is the banner scheduled to be selected at the time
+func (r *Rotation) IsScheduled(t time.Time) bool {
+	if r.StartsAt != nil && t.Before(*r.StartsAt) {
+		return false
+	}
+
+	if r.EndsAt != nil && !t.Before(*r.EndsAt) {
+		return false
+	}
+
+	return true
 }
 
 // Set datetime of create
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidSlot = errors.New("slot id must be greater than zero, its size and fallback banner can't be negative")
+)
+
+// The repository interface slot
+type SlotRepositoryInterface interface {
+	// Saves the slot settings, the slot without the enabled flag keeps the stored one
+	Save(ctx context.Context, slot Slot) (*Slot, error)
+
+	// Find one slot by id, returns nil if the slot has no settings
+	FindOneByID(ctx context.Context, slotID int) (*Slot, error)
+
+	// Find all the slots with settings ordered by id
+	FindAll(ctx context.Context) ([]*Slot, error)
+
+	// Removes the settings of the slot
+	Remove(ctx context.Context, slotID int) error
+}
+
+const (
+	// The strategy of the slot maximizes the clicks
+	RewardClicks = "clicks"
+
+	// The strategy of the slot maximizes the value of the conversions
+	RewardConversions = "conversions"
+)
+
+// Slot model, the size of the banners of the slot is in pixels.
+// The fallback banner is shown when none of the banners of the slot can be selected,
+// the disabled slot selects no banners, the slot without the flag is enabled
+type Slot struct {
+	ID               int        `json:"id" db:"id"`
+	Name             string     `json:"name,omitempty" db:"name"`
+	Description      string     `json:"description,omitempty" db:"description"`
+	Width            int        `json:"width,omitempty" db:"width"`
+	Height           int        `json:"height,omitempty" db:"height"`
+	Strategy         string     `json:"strategy,omitempty" db:"strategy"`
+	Parameters       Parameters `json:"parameters,omitempty" db:"parameters"`
+	Reward           string     `json:"reward,omitempty" db:"reward"`
+	FallbackBannerID int        `json:"fallbackBannerId,omitempty" db:"fallback_banner_id"`
+	Enabled          *bool      `json:"enabled,omitempty" db:"enabled"`
+}
+
+// Checks the id, the size and the fallback banner of the slot
+func (s *Slot) Validate() error {
+	if s.ID <= 0 || s.Width < 0 || s.Height < 0 || s.FallbackBannerID < 0 {
+		return ErrInvalidSlot
+	}
+
+	return nil
+}
+
+// Does the slot select banners, the slot without the flag is enabled
+func (s *Slot) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
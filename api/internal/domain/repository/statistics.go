@@ -11,16 +11,27 @@ const (
 
 	// Type of statistics click
 	StatisticsTypeClick = 2
+
+	// Type of statistics conversion, carries the value of the conversion
+	StatisticsTypeConversion = 3
 )
 
 // Statistics model
 type Statistics struct {
-	ID        int       `json:"id" db:"id"`
-	Type      int       `json:"type" db:"type"`
-	BannerID  int       `json:"bannerId" db:"banner_id"`
-	SlotID    int       `json:"slotId" db:"slot_id"`
-	GroupID   int       `json:"groupId" db:"group_id"`
-	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	// Id of the stored statistics, zero while the cached statistics are not flushed, then it is left out of the events
+	ID         int        `json:"id,omitempty" db:"id"`
+	Type       int        `json:"type" db:"type"`
+	BannerID   int        `json:"bannerId" db:"banner_id"`
+	SlotID     int        `json:"slotId" db:"slot_id"`
+	GroupID    int        `json:"groupId" db:"group_id"`
+	Value      float64    `json:"value" db:"value"`
+	Position   int        `json:"position,omitempty" db:"position"`
+	Strategy   string     `json:"strategy" db:"strategy"`
+	Attributes Attributes `json:"attributes,omitempty" db:"attributes"`
+	CreatedAt  time.Time  `json:"createdAt" db:"created_at"`
+
+	// Probability the strategy selected the banner of the view with, zero when it is unknown
+	Probability float64 `json:"probability,omitempty" db:"probability"`
 }
 
 // Is the view type
@@ -33,11 +44,19 @@ func (s *Statistics) IsTypeClick() bool {
 	return s.Type == StatisticsTypeClick
 }
 
+// Is the conversion type
+func (s *Statistics) IsTypeConversion() bool {
+	return s.Type == StatisticsTypeConversion
+}
+
 // The repository interface statistics
 type StatisticsRepositoryInterface interface {
 	// Adds statistics
 	Add(ctx context.Context, statistics Statistics) (*Statistics, error)
 
+	// Adds the statistics in one batch
+	AddBatch(ctx context.Context, statisticsList ...Statistics) error
+
 	// Find all the statistics by slot and group
 	FindAllBySlotIDAndGroupID(ctx context.Context, slotID int, groupID int) ([]*Statistics, error)
 
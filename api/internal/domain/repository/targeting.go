@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidTargeting = errors.New("targeting values can't be empty")
+	ErrInvalidTargets   = errors.New("targeting must be a json object")
+)
+
+const (
+	// Attribute of the request with the country code of the visitor
+	AttributeCountry = "country"
+
+	// Attribute of the request with the device type of the visitor
+	AttributeDevice = "device"
+
+	// Attribute of the request with the language of the visitor
+	AttributeLanguage = "language"
+)
+
+// Targeting of the banner, the banner is selected for the groups and the attributes of the request
+// within all the allowed values and out of all the denied ones, empty allowed values allow any value.
+// The request without the attribute is out of the allowed values of the attribute.
+// Countries and devices are compared case-insensitively, languages also match their regional variants
+type Targeting struct {
+	Groups          []int    `json:"groups,omitempty"`
+	DeniedGroups    []int    `json:"deniedGroups,omitempty"`
+	Countries       []string `json:"countries,omitempty"`
+	DeniedCountries []string `json:"deniedCountries,omitempty"`
+	Devices         []string `json:"devices,omitempty"`
+	DeniedDevices   []string `json:"deniedDevices,omitempty"`
+	Languages       []string `json:"languages,omitempty"`
+	DeniedLanguages []string `json:"deniedLanguages,omitempty"`
+}
+
+// Checks that the targeting has no empty values
+func (t Targeting) Validate() error {
+	lists := [][]string{
+		t.Countries,
+		t.DeniedCountries,
+		t.Devices,
+		t.DeniedDevices,
+		t.Languages,
+		t.DeniedLanguages,
+	}
+
+	for _, values := range lists {
+		for _, value := range values {
+			if strings.TrimSpace(value) == "" {
+				return ErrInvalidTargeting
+			}
+		}
+	}
+
+	return nil
+}
+
+// Does the targeting match the group and the attributes of the request
+func (t Targeting) Matches(groupID int, attributes Attributes) bool {
+	if len(t.Groups) > 0 && !containsGroup(t.Groups, groupID) {
+		return false
+	}
+
+	if containsGroup(t.DeniedGroups, groupID) {
+		return false
+	}
+
+	return matchesValue(t.Countries, t.DeniedCountries, attributes[AttributeCountry], strings.EqualFold) &&
+		matchesValue(t.Devices, t.DeniedDevices, attributes[AttributeDevice], strings.EqualFold) &&
+		matchesValue(t.Languages, t.DeniedLanguages, attributes[AttributeLanguage], matchesLanguage)
+}
+
+// Value returns the targeting encoded in json for the database
+func (t Targeting) Value() (driver.Value, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan decodes the targeting from the json stored in the database
+func (t *Targeting) Scan(src interface{}) error {
+	var data []byte
+
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return ErrInvalidTargets
+	}
+
+	return json.Unmarshal(data, t)
+}
+
+// Is the group in the groups
+func containsGroup(groups []int, groupID int) bool {
+	for _, group := range groups {
+		if group == groupID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Is the value within the allowed values and out of the denied ones
+func matchesValue(allowed []string, denied []string, value string, equal func(rule, value string) bool) bool {
+	if len(allowed) > 0 {
+		if value == "" {
+			return false
+		}
+
+		matches := false
+		for _, rule := range allowed {
+			if equal(rule, value) {
+				matches = true
+			}
+		}
+
+		if !matches {
+			return false
+		}
+	}
+
+	for _, rule := range denied {
+		if value != "" && equal(rule, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Does the language match the language of the rule or its regional variant, en matches en-US
+func matchesLanguage(rule string, language string) bool {
+	if strings.EqualFold(rule, language) {
+		return true
+	}
+
+	return len(language) > len(rule) && strings.EqualFold(language[:len(rule)], rule) &&
+		(language[len(rule)] == '-' || language[len(rule)] == '_')
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+)
+
+// The interface of the transactions over the repositories
+type TransactorInterface interface {
+	// Runs the function in one transaction, the repositories called with the context of the function
+	// take part in it, the transaction is rolled back when the function returns an error
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
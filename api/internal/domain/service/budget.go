@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// Sets the total and daily caps of the views and clicks of the banner, zero cap does not bound them,
+// the paced banner spreads its daily caps evenly over the day
+func (b *RotationService) SetBudget(
+	ctx context.Context,
+	bannerID int,
+	viewsCap int,
+	clicksCap int,
+	dailyViewsCap int,
+	dailyClicksCap int,
+	pacing bool,
+) (*repository.Rotation, error) {
+	rotation, err := b.RotationRepository.FindOneByBannerID(ctx, bannerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for rotation by banner id")
+	}
+
+	if rotation == nil || rotation.ID == 0 {
+		return nil, ErrRotationNotFound
+	}
+
+	rotation.ViewsCap = viewsCap
+	rotation.ClicksCap = clicksCap
+	rotation.DailyViewsCap = dailyViewsCap
+	rotation.DailyClicksCap = dailyClicksCap
+	rotation.Pacing = pacing
+
+	err = rotation.ValidateBudget()
+	if err != nil {
+		return nil, err
+	}
+
+	rotation, err = b.RotationRepository.Update(ctx, *rotation)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when saving the rotation budget")
+	}
+
+	return rotation, nil
+}
+
+// Leaves out the banners that reached their caps of the views and clicks in all the groups,
+// the day starts at the midnight of the location of the service
+func (b *RotationService) budgeted(
+	ctx context.Context,
+	slotID int,
+	rotations []*repository.Rotation,
+	now time.Time,
+) ([]*repository.Rotation, error) {
+	total := false
+	daily := false
+
+	for _, rotation := range rotations {
+		total = total || rotation.ViewsCap > 0 || rotation.ClicksCap > 0
+		daily = daily || rotation.DailyViewsCap > 0 || rotation.DailyClicksCap > 0
+	}
+
+	if !total && !daily {
+		return rotations, nil
+	}
+
+	location := b.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	local := now.In(location)
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	spent := make(map[int]repository.Banner)
+	spentToday := make(map[int]repository.Banner)
+
+	// the total caps are checked against the totals, only the daily caps need the hourly counters
+	if total {
+		totals, err := b.CountersRepository.FindTotalsBySlotID(ctx, slotID)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting counter totals for the budgets of banners")
+		}
+
+		for _, counters := range totals {
+			banner := spent[counters.BannerID]
+			banner.Views += float64(counters.Views)
+			banner.Clicks += float64(counters.Clicks)
+			spent[counters.BannerID] = banner
+		}
+	}
+
+	if daily {
+		// the period that the local midnight splits is loaded too
+		since := dayStart.Truncate(repository.CountersPeriod)
+
+		countersList, err := b.CountersRepository.FindAllBySlotIDSince(ctx, slotID, since)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting counters for the budgets of banners")
+		}
+
+		for _, counters := range countersList {
+			// the period that the local midnight splits counts toward today by its part after the midnight
+			today := todayPart(counters.Period, dayStart)
+			if today <= 0 {
+				continue
+			}
+
+			banner := spentToday[counters.BannerID]
+			banner.Views += today * float64(counters.Views)
+			banner.Clicks += today * float64(counters.Clicks)
+			spentToday[counters.BannerID] = banner
+		}
+	}
+
+	// the paced banner may spend the share of the daily caps of the hours of the day up to the current one,
+	// the hours are counted from the local midnight
+	paced := now.Sub(dayStart).Truncate(repository.CountersPeriod) + repository.CountersPeriod
+	share := paced.Seconds() / dayEnd.Sub(dayStart).Seconds()
+	if share > 1 {
+		share = 1
+	}
+
+	available := make([]*repository.Rotation, 0, len(rotations))
+
+	for _, rotation := range rotations {
+		totals := spent[rotation.BannerID]
+		today := spentToday[rotation.BannerID]
+
+		dailyShare := 1.0
+		if rotation.Pacing {
+			dailyShare = share
+		}
+
+		if isCapped(totals.Views, rotation.ViewsCap, 1) ||
+			isCapped(totals.Clicks, rotation.ClicksCap, 1) ||
+			isCapped(today.Views, rotation.DailyViewsCap, dailyShare) ||
+			isCapped(today.Clicks, rotation.DailyClicksCap, dailyShare) {
+			continue
+		}
+
+		available = append(available, rotation)
+	}
+
+	return available, nil
+}
+
+// Returns the part of the counters period after the start of the day
+func todayPart(period time.Time, dayStart time.Time) float64 {
+	end := period.Add(repository.CountersPeriod)
+
+	switch {
+	case !end.After(dayStart):
+		return 0
+	case !period.Before(dayStart):
+		return 1
+	}
+
+	return end.Sub(dayStart).Seconds() / repository.CountersPeriod.Seconds()
+}
+
+// Did the spent amount reach the share of the cap, zero cap does not bound it
+func isCapped(spent float64, limit int, share float64) bool {
+	return limit > 0 && spent >= float64(limit)*share
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/koind/banner-rotation/api/internal/storage/memory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRotationService_SelectBannerByBudget(t *testing.T) {
+	countersRepository := memory.NewCountersRepository()
+	rotationService := RotationService{
+		CountersRepository: countersRepository,
+	}
+
+	ctx := context.Background()
+	now := time.Date(2019, 12, 2, 5, 30, 0, 0, time.UTC)
+	today := now.Truncate(repository.CountersPeriod)
+	yesterday := today.Add(-24 * time.Hour)
+
+	countersRepository.Add(
+		ctx,
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: yesterday, Views: 100},
+		repository.Counters{SlotID: 1, GroupID: 2, BannerID: 1, Period: today, Views: 10},
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 2, Period: today, Clicks: 5},
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 3, Period: today, Views: 6},
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 4, Period: yesterday, Views: 200},
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 5, Period: today, Views: 5},
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 7, Period: today.Add(-8 * time.Hour), Views: 1},
+	)
+
+	rotations := []*repository.Rotation{
+		{BannerID: 1, SlotID: 1, ViewsCap: 110},
+		{BannerID: 2, SlotID: 1, DailyClicksCap: 5},
+		{BannerID: 3, SlotID: 1, DailyViewsCap: 24, Pacing: true},
+		{BannerID: 4, SlotID: 1, DailyViewsCap: 100},
+		{BannerID: 5, SlotID: 1, DailyViewsCap: 24, Pacing: true},
+		{BannerID: 6, SlotID: 1},
+		{BannerID: 7, SlotID: 1, DailyViewsCap: 1},
+	}
+
+	budgeted, err := rotationService.budgeted(ctx, 1, rotations, now)
+	assert.Nil(t, err)
+
+	bannerIDs := make([]int, 0, len(budgeted))
+	for _, rotation := range budgeted {
+		bannerIDs = append(bannerIDs, rotation.BannerID)
+	}
+
+	assert.Equal(t, []int{4, 5, 6, 7}, bannerIDs, "banners should be left out once they reached their caps")
+
+	err = countersRepository.RemoveBefore(ctx, today.Add(-12*time.Hour))
+	assert.Nil(t, err)
+
+	budgeted, err = rotationService.budgeted(ctx, 1, rotations[:1], now)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(budgeted), "total caps should count the views of the removed hourly counters")
+
+	rotationService.Location, _ = time.LoadLocation("Asia/Almaty")
+
+	budgeted, err = rotationService.budgeted(ctx, 1, rotations[6:], now)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(budgeted), "views of the evening of yesterday in UTC are views of today in the location")
+
+	// the local midnight in Kolkata is at 18:30 UTC, the half of the views of the hour after 18:00 UTC are of today
+	rotationService.Location, _ = time.LoadLocation("Asia/Kolkata")
+	now = time.Date(2019, 12, 2, 19, 0, 0, 0, time.UTC)
+
+	countersRepository.Add(
+		ctx,
+		repository.Counters{SlotID: 2, GroupID: 1, BannerID: 8, Period: now.Add(-time.Hour), Views: 10},
+		repository.Counters{SlotID: 2, GroupID: 1, BannerID: 9, Period: now.Add(-time.Hour), Views: 10},
+		repository.Counters{SlotID: 2, GroupID: 1, BannerID: 10, Period: now.Add(-2 * time.Hour), Views: 10},
+	)
+
+	rotations = []*repository.Rotation{
+		{BannerID: 8, SlotID: 2, DailyViewsCap: 5},
+		{BannerID: 9, SlotID: 2, DailyViewsCap: 6},
+		{BannerID: 10, SlotID: 2, DailyViewsCap: 1},
+	}
+
+	budgeted, err = rotationService.budgeted(ctx, 2, rotations, now)
+	assert.Nil(t, err)
+
+	bannerIDs = make([]int, 0, len(budgeted))
+	for _, rotation := range budgeted {
+		bannerIDs = append(bannerIDs, rotation.BannerID)
+	}
+
+	assert.Equal(t, []int{9, 10}, bannerIDs, "hours should be counted from the local midnight")
+}
+
+func TestRotationService_SetBudget(t *testing.T) {
+	rotationService := newTestRotationService(t)
+
+	ctx := context.Background()
+	_, err := rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, ViewsCap: -1})
+	assert.Equal(t, repository.ErrInvalidBudget, err)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	rotation, err := rotationService.SetBudget(ctx, 1, 3, 0, 0, 0, false)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, rotation.ViewsCap)
+	assert.Equal(t, "Banner 1", rotation.Description)
+
+	views := map[int]int{}
+	for i := 0; i < 10; i++ {
+		bannerID, _, err := rotationService.SelectBanner(ctx, 1, i%2+1, "", nil)
+		assert.Nil(t, err)
+		views[bannerID]++
+	}
+
+	assert.Equal(t, map[int]int{1: 3, 2: 7}, views, "banner should not be selected over its cap in all the groups")
+
+	rotationService.SetBudget(ctx, 2, 7, 0, 0, 0, false)
+
+	_, _, err = rotationService.SelectBanner(ctx, 1, 1, "", nil)
+	assert.Equal(t, ErrRotationsListEmpty, errors.Cause(err))
+
+	_, err = rotationService.SetBudget(ctx, 1, 0, 0, -1, 0, false)
+	assert.Equal(t, repository.ErrInvalidBudget, err)
+
+	_, err = rotationService.SetBudget(ctx, 3, 0, 0, 0, 0, false)
+	assert.Equal(t, ErrRotationNotFound, err)
+}
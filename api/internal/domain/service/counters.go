@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// Shortest age of the hourly counters that are kept, it covers the day of the daily budgets in any timezone
+const MinCountersHorizon = 48 * time.Hour
+
+// Returns the counters the strategy of the slot learns from in the group: the hourly counters
+// when the strategy discounts them by their age, the totals without the period otherwise
+func (b *RotationService) counters(ctx context.Context, slot repository.Slot, groupID int) ([]*repository.Counters, error) {
+	if b.Strategies.Discount(slot.Strategy) != nil {
+		return b.CountersRepository.FindAllBySlotIDAndGroupID(ctx, slot.ID, groupID)
+	}
+
+	return b.CountersRepository.FindTotalsBySlotIDAndGroupID(ctx, slot.ID, groupID)
+}
+
+// Removes the hourly counters older than the longest horizon of the discounting strategies,
+// the share window and the daily budgets, the totals are kept.
+// Nothing is removed when a discounting strategy never forgets the observations
+func (b *RotationService) RemoveExpiredCounters(ctx context.Context) error {
+	horizon, has := b.Strategies.Horizon()
+	if !has {
+		return nil
+	}
+
+	window := b.ShareWindow
+	if window <= 0 {
+		window = DefaultShareWindow
+	}
+
+	if horizon < window {
+		horizon = window
+	}
+
+	if horizon < MinCountersHorizon {
+		horizon = MinCountersHorizon
+	}
+
+	err := b.CountersRepository.RemoveBefore(ctx, time.Now().UTC().Add(-horizon))
+	if err != nil {
+		return errors.Wrap(err, "error when removing expired counters")
+	}
+
+	return nil
+}
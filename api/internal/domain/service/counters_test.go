@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/config"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/koind/banner-rotation/api/internal/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRotationService_RemoveExpiredCounters(t *testing.T) {
+	rotationService := newTestRotationService(t)
+	countersRepository := rotationService.CountersRepository.(*memory.CountersRepository)
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	monthAgo := now.AddDate(0, -1, 0)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	countersRepository.Add(
+		ctx,
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: monthAgo.Truncate(time.Hour), Views: 1000, Clicks: 500},
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: now.Truncate(time.Hour), Views: 100},
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 2, Period: now.Truncate(time.Hour), Views: 1000, Clicks: 300},
+	)
+
+	err := rotationService.RemoveExpiredCounters(ctx)
+	assert.Nil(t, err)
+
+	countersList, err := countersRepository.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Len(t, countersList, 2, "counters older than the horizon should be removed")
+
+	totals, err := countersRepository.FindTotalsBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+
+	views := 0
+	for _, counters := range totals {
+		views += counters.Views
+	}
+	assert.Equal(t, 2100, views, "totals should be kept")
+
+	testCases := []struct {
+		strategy         string
+		expectedBannerID int
+	}{
+		{algorithm.StrategyUCB1, 1},
+		{algorithm.StrategySlidingWindowUCB, 2},
+	}
+
+	for _, testCase := range testCases {
+		_, err := rotationService.SetStrategy(ctx, 1, testCase.strategy, nil)
+		assert.Nil(t, err)
+
+		bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedBannerID, bannerID, testCase.strategy)
+	}
+}
+
+func TestRotationService_RemoveExpiredCountersKeepsUnforgotten(t *testing.T) {
+	options := config.DefaultStrategies()
+	options.HalfLife = 0
+
+	countersRepository := memory.NewCountersRepository()
+	rotationService := RotationService{
+		CountersRepository: countersRepository,
+		Strategies:         algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), options),
+	}
+
+	ctx := context.Background()
+	yearAgo := time.Now().UTC().AddDate(-1, 0, 0).Truncate(time.Hour)
+
+	countersRepository.Add(ctx, repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: yearAgo, Views: 10})
+
+	err := rotationService.RemoveExpiredCounters(ctx)
+	assert.Nil(t, err)
+
+	countersList, err := countersRepository.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Len(t, countersList, 1, "counters should be kept while a strategy never forgets them")
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// Default window the impressions of the banners to the visitor are capped over
+const DefaultFrequencyWindow = 24 * time.Hour
+
+// Leaves out the banners the visitor was shown the cap times over the frequency window,
+// the visitor without id or the service without cap is not capped
+func (b *RotationService) uncapped(
+	ctx context.Context,
+	visitorID string,
+	rotations []*repository.Rotation,
+	now time.Time,
+) ([]*repository.Rotation, error) {
+	if visitorID == "" || b.FrequencyCap <= 0 || b.ImpressionsRepository == nil {
+		return rotations, nil
+	}
+
+	impressions, err := b.ImpressionsRepository.FindAllByVisitorID(ctx, visitorID, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting impressions of the visitor")
+	}
+
+	shown := make(map[int]int)
+	for _, impression := range impressions {
+		shown[impression.BannerID]++
+	}
+
+	uncapped := make([]*repository.Rotation, 0, len(rotations))
+
+	for _, rotation := range rotations {
+		if shown[rotation.BannerID] < b.FrequencyCap {
+			uncapped = append(uncapped, rotation)
+		}
+	}
+
+	return uncapped, nil
+}
+
+// Records the impressions of the banners to the visitor, they expire after the frequency window
+func (b *RotationService) remember(
+	ctx context.Context,
+	visitorID string,
+	rotations []*repository.Rotation,
+	now time.Time,
+) error {
+	if visitorID == "" || b.FrequencyCap <= 0 || b.ImpressionsRepository == nil {
+		return nil
+	}
+
+	window := b.FrequencyWindow
+	if window <= 0 {
+		window = DefaultFrequencyWindow
+	}
+
+	impressions := make([]repository.Impression, 0, len(rotations))
+
+	for _, rotation := range rotations {
+		impressions = append(impressions, repository.Impression{
+			VisitorID: visitorID,
+			BannerID:  rotation.BannerID,
+			SlotID:    rotation.SlotID,
+			CreatedAt: now,
+			ExpiresAt: now.Add(window),
+		})
+	}
+
+	err := b.ImpressionsRepository.Add(ctx, impressions...)
+	if err != nil {
+		return errors.Wrap(err, "error when saving impressions of the visitor")
+	}
+
+	return nil
+}
+
+// Removes the impressions of the visitors that left the frequency window
+func (b *RotationService) ExpireImpressions(ctx context.Context) error {
+	if b.ImpressionsRepository == nil {
+		return nil
+	}
+
+	err := b.ImpressionsRepository.RemoveExpired(ctx, time.Now().UTC())
+	if err != nil {
+		return errors.Wrap(err, "error when removing expired impressions")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/koind/banner-rotation/api/internal/storage/memory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRotationService_SelectBannerByFrequency(t *testing.T) {
+	impressionsRepository := memory.NewImpressionsRepository()
+	rotationService := newTestRotationService(t)
+	rotationService.ImpressionsRepository = impressionsRepository
+	rotationService.FrequencyCap = 2
+
+	ctx := context.Background()
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1})
+
+	shown := map[int]int{}
+	for i := 0; i < 4; i++ {
+		bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "visitor", nil)
+		assert.Nil(t, err)
+		shown[bannerID]++
+	}
+
+	assert.Equal(t, map[int]int{1: 2, 2: 2}, shown, "visitor should not be shown the banner over the cap")
+
+	_, _, err := rotationService.SelectBanner(ctx, 1, 1, "visitor", nil)
+	assert.Equal(t, ErrRotationsListEmpty, errors.Cause(err))
+
+	_, _, err = rotationService.SelectBanner(ctx, 1, 1, "other visitor", nil)
+	assert.Nil(t, err, "other visitors should not be capped")
+
+	_, _, err = rotationService.SelectBanner(ctx, 1, 1, "", nil)
+	assert.Nil(t, err, "visitor without id should not be capped")
+
+	impressions, _ := impressionsRepository.FindAllByVisitorID(ctx, "visitor", time.Now().UTC())
+	assert.Equal(t, 4, len(impressions))
+
+	impressionsRepository.RemoveExpired(ctx, time.Now().UTC().Add(DefaultFrequencyWindow))
+
+	impressions, _ = impressionsRepository.FindAllByVisitorID(ctx, "visitor", time.Now().UTC())
+	assert.Equal(t, 0, len(impressions), "impressions should expire after the frequency window")
+
+	_, _, err = rotationService.SelectBanner(ctx, 1, 1, "visitor", nil)
+	assert.Nil(t, err, "visitor should be shown the banners again once the impressions expired")
+
+	yesterday := time.Now().UTC().Add(-DefaultFrequencyWindow)
+	impressionsRepository.Add(ctx, repository.Impression{
+		VisitorID: "returning visitor",
+		BannerID:  1,
+		SlotID:    1,
+		CreatedAt: yesterday,
+		ExpiresAt: yesterday.Add(time.Hour),
+	})
+
+	_, _, err = rotationService.SelectBanner(ctx, 1, 1, "returning visitor", nil)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		1,
+		len(impressionsRepository.DB["returning visitor"]),
+		"expired impressions of the visitor should be removed when the new ones are added",
+	)
+}
@@ -5,10 +5,18 @@ import (
 	"github.com/koind/banner-rotation/api/internal/algorithm"
 	"github.com/koind/banner-rotation/api/internal/domain/repository"
 	"github.com/pkg/errors"
+	"math"
+	"sort"
+	"time"
 )
 
 var (
-	ErrRotationsListEmpty = errors.New("rotations list can't be empty")
+	ErrRotationsListEmpty     = errors.New("rotations list can't be empty")
+	ErrInvalidConversionValue = errors.New("conversion value can't be negative")
+	ErrUnknownReward          = errors.New("unknown reward")
+	ErrInvalidCount           = errors.New("count of banners must be greater than zero")
+	ErrRotationNotFound       = errors.New("rotation not found")
+	ErrRotationArchived       = errors.New("archived rotation can't change its status")
 )
 
 // Rotation service
@@ -16,10 +24,77 @@ type RotationService struct {
 	StatisticsService    StatisticsServiceInterface
 	RotationRepository   repository.RotationRepositoryInterface
 	StatisticsRepository repository.StatisticsRepositoryInterface
+	CountersRepository   repository.CountersRepositoryInterface
+	SlotRepository       repository.SlotRepositoryInterface
+	Strategies           *algorithm.Registry
+
+	// Conversion values are divided by it to keep the rewards within [0, 1], zero keeps the values as is
+	MaxConversionValue float64
+
+	// Click model that debiases the views by the position of the banner: position, cascade or none
+	ClickModel string
+
+	// Examination probability of every position of the position-based click model
+	PositionBias []float64
+
+	// Timezone of the dayparting of the rotations without their own timezone, nil for UTC
+	Location *time.Location
+
+	// Window the shares of the views of the banners are tracked over, zero for the default window
+	ShareWindow time.Duration
+
+	// Impressions of the banners to the visitors, nil does not cap the visitors
+	ImpressionsRepository repository.ImpressionsRepositoryInterface
+
+	// Number of times the banner is shown to the visitor over the frequency window, zero does not cap it
+	FrequencyCap int
+
+	// Window the impressions of the banners to the visitor are capped over, zero for the default window
+	FrequencyWindow time.Duration
 }
 
 // Adds a new banner to the rotation
 func (b *RotationService) Add(ctx context.Context, rotation repository.Rotation) (*repository.Rotation, error) {
+	err := rotation.ValidateStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rotation.ValidatePrior()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rotation.ValidateSchedule()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rotation.ValidateDayparting()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rotation.ValidateShare()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rotation.ValidateBudget()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rotation.ValidateTargeting()
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.validateMinShares(ctx, rotation)
+	if err != nil {
+		return nil, err
+	}
+
 	newRotation, err := b.RotationRepository.Add(ctx, rotation)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when adding banner in the rotation")
@@ -28,6 +103,145 @@ func (b *RotationService) Add(ctx context.Context, rotation repository.Rotation)
 	return newRotation, nil
 }
 
+// Pauses the banner, it is not selected until it is resumed, its statistics are kept
+func (b *RotationService) Pause(ctx context.Context, bannerID int) (*repository.Rotation, error) {
+	return b.SetStatus(ctx, bannerID, repository.RotationStatusPaused)
+}
+
+// Resumes the paused banner with its statistics
+func (b *RotationService) Resume(ctx context.Context, bannerID int) (*repository.Rotation, error) {
+	return b.SetStatus(ctx, bannerID, repository.RotationStatusActive)
+}
+
+// Archives the banner, it is not selected anymore, its statistics are kept
+func (b *RotationService) Archive(ctx context.Context, bannerID int) (*repository.Rotation, error) {
+	return b.SetStatus(ctx, bannerID, repository.RotationStatusArchived)
+}
+
+// Sets the status of the banner, the archived banner keeps its status
+func (b *RotationService) SetStatus(ctx context.Context, bannerID int, status string) (*repository.Rotation, error) {
+	rotation, err := b.RotationRepository.FindOneByBannerID(ctx, bannerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for rotation by banner id")
+	}
+
+	if rotation == nil || rotation.ID == 0 {
+		return nil, ErrRotationNotFound
+	}
+
+	if rotation.Status == repository.RotationStatusArchived && status != repository.RotationStatusArchived {
+		return nil, ErrRotationArchived
+	}
+
+	rotation.Status = status
+
+	err = rotation.ValidateStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	rotation, err = b.RotationRepository.Update(ctx, *rotation)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when saving the rotation status")
+	}
+
+	return rotation, nil
+}
+
+// Sets when the banner joins and leaves the slot, nil start or end leaves the rotation open on that side
+func (b *RotationService) SetSchedule(
+	ctx context.Context,
+	bannerID int,
+	startsAt *time.Time,
+	endsAt *time.Time,
+) (*repository.Rotation, error) {
+	rotation, err := b.RotationRepository.FindOneByBannerID(ctx, bannerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for rotation by banner id")
+	}
+
+	if rotation == nil || rotation.ID == 0 {
+		return nil, ErrRotationNotFound
+	}
+
+	rotation.StartsAt = startsAt
+	rotation.EndsAt = endsAt
+
+	err = rotation.ValidateSchedule()
+	if err != nil {
+		return nil, err
+	}
+
+	rotation, err = b.RotationRepository.Update(ctx, *rotation)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when saving the rotation schedule")
+	}
+
+	return rotation, nil
+}
+
+// Sets the hours and the weekdays the banner is selected at, empty timezone takes the configured one
+func (b *RotationService) SetDayparting(
+	ctx context.Context,
+	bannerID int,
+	timezone string,
+	dayparting repository.Dayparting,
+) (*repository.Rotation, error) {
+	rotation, err := b.RotationRepository.FindOneByBannerID(ctx, bannerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for rotation by banner id")
+	}
+
+	if rotation == nil || rotation.ID == 0 {
+		return nil, ErrRotationNotFound
+	}
+
+	rotation.Timezone = timezone
+	rotation.Dayparting = dayparting
+
+	err = rotation.ValidateDayparting()
+	if err != nil {
+		return nil, err
+	}
+
+	rotation, err = b.RotationRepository.Update(ctx, *rotation)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when saving the rotation dayparting")
+	}
+
+	return rotation, nil
+}
+
+// Sets the groups and the attributes of the requests the banner is selected for, nil targets any request
+func (b *RotationService) SetTargeting(
+	ctx context.Context,
+	bannerID int,
+	targeting *repository.Targeting,
+) (*repository.Rotation, error) {
+	rotation, err := b.RotationRepository.FindOneByBannerID(ctx, bannerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for rotation by banner id")
+	}
+
+	if rotation == nil || rotation.ID == 0 {
+		return nil, ErrRotationNotFound
+	}
+
+	rotation.Targeting = targeting
+
+	err = rotation.ValidateTargeting()
+	if err != nil {
+		return nil, err
+	}
+
+	rotation, err = b.RotationRepository.Update(ctx, *rotation)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when saving the rotation targeting")
+	}
+
+	return rotation, nil
+}
+
 // Removes the banner from the rotation
 func (b *RotationService) Remove(ctx context.Context, bannerID int) error {
 	err := b.RotationRepository.Remove(ctx, bannerID)
@@ -38,13 +252,21 @@ func (b *RotationService) Remove(ctx context.Context, bannerID int) error {
 	return nil
 }
 
-// Increases the jump count by 1 for the specified banner in the specified group
+// Increases the jump count by 1 for the specified banner in the specified group,
+// attributes are the attributes of the request the banner was selected for
 func (b *RotationService) SetTransition(
 	ctx context.Context,
 	rotation repository.Rotation,
 	groupID int,
+	attributes repository.Attributes,
 ) (*repository.Statistics, error) {
-	statistics, err := b.StatisticsService.Save(ctx, rotation, groupID, repository.StatisticsTypeClick)
+	statistics, err := b.StatisticsService.Save(ctx, repository.Statistics{
+		Type:       repository.StatisticsTypeClick,
+		BannerID:   rotation.BannerID,
+		SlotID:     rotation.SlotID,
+		GroupID:    groupID,
+		Attributes: attributes,
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "error when set the transition")
 	}
@@ -52,87 +274,543 @@ func (b *RotationService) SetTransition(
 	return statistics, nil
 }
 
-// Selects a banner to display
+// Records the conversion of the banner in the specified group with its value,
+// attributes are the attributes of the request the banner was selected for
+func (b *RotationService) SetConversion(
+	ctx context.Context,
+	rotation repository.Rotation,
+	groupID int,
+	value float64,
+	attributes repository.Attributes,
+) (*repository.Statistics, error) {
+	if value < 0 {
+		return nil, ErrInvalidConversionValue
+	}
+
+	statistics, err := b.StatisticsService.Save(ctx, repository.Statistics{
+		Type:       repository.StatisticsTypeConversion,
+		BannerID:   rotation.BannerID,
+		SlotID:     rotation.SlotID,
+		GroupID:    groupID,
+		Value:      value,
+		Attributes: attributes,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error when set the conversion")
+	}
+
+	return statistics, nil
+}
+
+// Sets what the strategy of the slot maximizes: the clicks or the value of the conversions
+func (b *RotationService) SetReward(ctx context.Context, slotID int, reward string) (*repository.Slot, error) {
+	if reward != repository.RewardClicks && reward != repository.RewardConversions {
+		return nil, ErrUnknownReward
+	}
+
+	slot, err := b.SlotRepository.FindOneByID(ctx, slotID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for slot by id")
+	}
+
+	if slot == nil {
+		slot = &repository.Slot{ID: slotID}
+	}
+
+	slot.Reward = reward
+
+	slot, err = b.SlotRepository.Save(ctx, *slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when saving the slot reward")
+	}
+
+	return slot, nil
+}
+
+// Sets the strategy that selects banners in the slot,
+// parameters override the configured parameters of the strategy for the slot
+func (b *RotationService) SetStrategy(
+	ctx context.Context,
+	slotID int,
+	strategy string,
+	parameters repository.Parameters,
+) (*repository.Slot, error) {
+	if !b.Strategies.Has(strategy) {
+		return nil, algorithm.ErrUnknownStrategy
+	}
+
+	err := b.Strategies.Validate(strategy, algorithm.Parameters(parameters))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid strategy parameters")
+	}
+
+	slot, err := b.SlotRepository.FindOneByID(ctx, slotID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for slot by id")
+	}
+
+	if slot == nil {
+		slot = &repository.Slot{ID: slotID}
+	}
+
+	slot.Strategy = strategy
+	slot.Parameters = parameters
+
+	slot, err = b.SlotRepository.Save(ctx, *slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when saving the slot strategy")
+	}
+
+	return slot, nil
+}
+
+// Returns the settings of the slot, the default strategy and reward are used when the slot has none
+func (b *RotationService) slot(ctx context.Context, slotID int) (*repository.Slot, error) {
+	slot, err := b.SlotRepository.FindOneByID(ctx, slotID)
+	if err != nil {
+		return nil, err
+	}
+
+	if slot == nil {
+		slot = &repository.Slot{ID: slotID}
+	}
+
+	if slot.Strategy == "" {
+		slot.Strategy = algorithm.DefaultStrategy
+	}
+
+	if slot.Reward == "" {
+		slot.Reward = repository.RewardClicks
+	}
+
+	return slot, nil
+}
+
+// Selects a banner to display, attributes are used by the contextual strategies,
+// the visitor is not shown the banner over the frequency cap, empty visitor id is not capped
 func (b *RotationService) SelectBanner(
 	ctx context.Context,
 	slotID int,
 	groupID int,
+	visitorID string,
+	attributes repository.Attributes,
 ) (int, *repository.Statistics, error) {
+	bannerIDs, statisticsList, err := b.SelectBanners(ctx, slotID, groupID, visitorID, 1, attributes)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return bannerIDs[0], statisticsList[0], nil
+}
+
+// Selects count distinct banners to display ranked by the strategy, a view is recorded for every banner,
+// fewer banners are returned when the slot has less than count banners,
+// only the fallback banner of the slot is returned when none of its banners can be selected
+func (b *RotationService) SelectBanners(
+	ctx context.Context,
+	slotID int,
+	groupID int,
+	visitorID string,
+	count int,
+	attributes repository.Attributes,
+) ([]int, []*repository.Statistics, error) {
+	if count < 1 {
+		return nil, nil, ErrInvalidCount
+	}
+
+	slot, err := b.slot(ctx, slotID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error when searching for the slot strategy")
+	}
+
+	if !slot.IsEnabled() {
+		return nil, nil, ErrSlotDisabled
+	}
+
 	rotations, err := b.RotationRepository.FindAllBySlotID(ctx, slotID)
 	if err != nil {
-		return 0, nil, errors.Wrap(err, "error when searching for rotations by slot id for banner selection")
+		return nil, nil, errors.Wrap(err, "error when searching for rotations by slot id for banner selection")
+	}
+
+	now := time.Now().UTC()
+
+	rotations, err = b.selectable(rotations, now)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error when checking the schedule of rotations")
+	}
+
+	rotations = b.targeted(rotations, groupID, attributes)
+
+	rotations, err = b.budgeted(ctx, slotID, rotations, now)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error when checking the budgets of rotations")
 	}
 
-	statisticsList, err := b.StatisticsRepository.FindAllBySlotIDAndGroupID(ctx, slotID, groupID)
+	rotations, err = b.uncapped(ctx, visitorID, rotations, now)
 	if err != nil {
-		return 0, nil, errors.Wrap(err, "error getting statistics for a selection of banner")
+		return nil, nil, errors.Wrap(err, "error when checking the frequency cap of the visitor")
+	}
+
+	if len(rotations) == 0 && slot.FallbackBannerID > 0 {
+		return b.fallback(ctx, *slot, groupID, attributes)
+	}
+
+	var define func(rotations []*repository.Rotation) (*repository.Rotation, error)
+
+	// probabilities the strategy selected the banners with, they are saved with the views
+	probabilities := make(map[int]float64)
+	if b.Strategies.IsContextual(slot.Strategy) {
+		// the contextual strategies learn from the attributes of every statistics
+		var statisticsList []*repository.Statistics
+
+		statisticsList, err = b.StatisticsRepository.FindAllBySlotIDAndGroupID(ctx, slotID, groupID)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error getting statistics for a selection of banner")
+		}
+
+		define = func(rotations []*repository.Rotation) (*repository.Rotation, error) {
+			return b.defineBannerByContext(rotations, statisticsList, *slot, attributes)
+		}
+	} else {
+		var countersList []*repository.Counters
+		var priors map[int]repository.Banner
+
+		countersList, err = b.counters(ctx, *slot, groupID)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error getting counters for a selection of banner")
+		}
+
+		priors, err = b.priors(ctx, rotations, countersList, *slot, groupID)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error getting priors for a selection of banner")
+		}
+
+		define = func(rotations []*repository.Rotation) (*repository.Rotation, error) {
+			rotation, probability, err := b.defineBanner(rotations, countersList, priors, *slot, now)
+			if err != nil {
+				return nil, err
+			}
+
+			probabilities[rotation.BannerID] = probability
+
+			return rotation, nil
+		}
 	}
 
-	rotation, err := b.defineBanner(rotations, statisticsList)
+	for _, rotation := range rotations {
+		if !rotation.HasShare() {
+			continue
+		}
+
+		shares, err := b.shares(ctx, slotID, now)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error getting shares for a selection of banner")
+		}
+
+		define = b.constrain(define, shares)
+
+		break
+	}
+
+	selected, err := b.defineBanners(rotations, count, define)
 	if err != nil {
-		return 0, nil, errors.Wrap(err, "error while banner definition")
+		return nil, nil, errors.Wrap(err, "error while banner definition")
 	}
 
-	statistics, err := b.StatisticsService.Save(ctx, *rotation, groupID, repository.StatisticsTypeView)
+	bannerIDs := make([]int, 0, len(selected))
+	statisticsList := make([]*repository.Statistics, 0, len(selected))
+
+	for i, rotation := range selected {
+		statistics, err := b.StatisticsService.Save(ctx, repository.Statistics{
+			Type:        repository.StatisticsTypeView,
+			BannerID:    rotation.BannerID,
+			SlotID:      rotation.SlotID,
+			GroupID:     groupID,
+			Position:    i + 1,
+			Strategy:    slot.Strategy,
+			Attributes:  attributes,
+			Probability: probabilities[rotation.BannerID],
+		})
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error while save view")
+		}
+
+		bannerIDs = append(bannerIDs, rotation.BannerID)
+		statisticsList = append(statisticsList, statistics)
+	}
+
+	err = b.remember(ctx, visitorID, selected, now)
 	if err != nil {
-		return 0, nil, errors.Wrap(err, "error while save view")
+		return nil, nil, err
 	}
 
-	return rotation.BannerID, statistics, nil
+	return bannerIDs, statisticsList, nil
 }
 
-// Determines which banner should be displayed
-func (b *RotationService) defineBanner(
+// Returns the active rotations scheduled to be selected at the time by their dates and dayparting,
+// the other banners are left out of the arms, so their statistics are kept as is
+func (b *RotationService) selectable(rotations []*repository.Rotation, t time.Time) ([]*repository.Rotation, error) {
+	scheduled := make([]*repository.Rotation, 0, len(rotations))
+
+	for _, rotation := range rotations {
+		if !rotation.IsActive() || !rotation.IsScheduled(t) {
+			continue
+		}
+
+		dayparted, err := rotation.IsDayparted(t, b.Location)
+		if err != nil {
+			return nil, err
+		}
+
+		if dayparted {
+			scheduled = append(scheduled, rotation)
+		}
+	}
+
+	return scheduled, nil
+}
+
+// Returns the rotations targeting the group and the attributes of the request,
+// the other banners are left out before the strategy
+func (b *RotationService) targeted(
 	rotations []*repository.Rotation,
-	statisticsList []*repository.Statistics,
-) (*repository.Rotation, error) {
+	groupID int,
+	attributes repository.Attributes,
+) []*repository.Rotation {
+	targeted := make([]*repository.Rotation, 0, len(rotations))
+
+	for _, rotation := range rotations {
+		if rotation.IsTargeted(groupID, attributes) {
+			targeted = append(targeted, rotation)
+		}
+	}
+
+	return targeted
+}
+
+// Ranks count distinct banners, every banner is selected by the strategy among the banners not selected yet
+func (b *RotationService) defineBanners(
+	rotations []*repository.Rotation,
+	count int,
+	define func(rotations []*repository.Rotation) (*repository.Rotation, error),
+) ([]*repository.Rotation, error) {
 	if len(rotations) <= 0 {
 		return nil, ErrRotationsListEmpty
 	}
 
+	remaining := make([]*repository.Rotation, len(rotations))
+	copy(remaining, rotations)
+
+	selected := make([]*repository.Rotation, 0, count)
+
+	for len(selected) < count && len(remaining) > 0 {
+		rotation, err := define(remaining)
+		if err != nil {
+			return nil, err
+		}
+
+		selected = append(selected, rotation)
+
+		for i, r := range remaining {
+			if r.BannerID == rotation.BannerID {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return selected, nil
+}
+
+// Returns the prior pseudo-counts of the banners that have a prior,
+// the prior clicks are the prior reward when the slot maximizes the conversions
+func (b *RotationService) priors(
+	ctx context.Context,
+	rotations []*repository.Rotation,
+	countersList []*repository.Counters,
+	slot repository.Slot,
+	groupID int,
+) (map[int]repository.Banner, error) {
+	priors := make(map[int]repository.Banner)
+
+	for _, rotation := range rotations {
+		if rotation.Prior == repository.PriorNone || rotation.PriorViews <= 0 {
+			continue
+		}
+
+		views, reward := rotation.PriorViews, rotation.PriorClicks
+
+		switch rotation.Prior {
+		case repository.PriorSlot:
+			bannerID := rotation.BannerID
+			slotViews, slotReward := b.observed(countersList, slot, func(c *repository.Counters) bool {
+				return c.BannerID != bannerID
+			})
+
+			if slotViews <= 0 {
+				continue
+			}
+
+			reward = views * slotReward / slotViews
+		case repository.PriorBanner:
+			history, err := b.CountersRepository.FindTotalsByBannerIDAndGroupID(ctx, rotation.BannerID, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			bannerViews, bannerReward := b.observed(history, slot, func(c *repository.Counters) bool {
+				return c.SlotID != slot.ID
+			})
+
+			if bannerViews <= 0 {
+				continue
+			}
+
+			reward = views * bannerReward / bannerViews
+		}
+
+		priors[rotation.BannerID] = repository.Banner{
+			ID:          rotation.BannerID,
+			Views:       views,
+			Clicks:      reward,
+			Conversions: reward,
+		}
+	}
+
+	return priors, nil
+}
+
+// Returns the views and the reward of the matching counters,
+// the reward is the clicks or the conversions depending on the slot
+func (b *RotationService) observed(
+	countersList []*repository.Counters,
+	slot repository.Slot,
+	match func(counters *repository.Counters) bool,
+) (views float64, reward float64) {
+	for _, counters := range countersList {
+		if !match(counters) {
+			continue
+		}
+
+		views += float64(counters.Views)
+
+		if slot.Reward == repository.RewardConversions {
+			reward += b.conversionReward(counters.Value, counters.Conversions)
+		} else {
+			reward += float64(counters.Clicks)
+		}
+	}
+
+	return views, reward
+}
+
+// Determines which banner should be displayed from the counters of the banners, the reward of the banner
+// is its clicks or the value of its conversions depending on the slot,
+// the counters are weighed by the age of their period when the strategy discounts old observations,
+// the views are weighed by the examination of their position by the click model,
+// the reward is divided by the probabilities of the views when the strategy learns from the estimated rewards,
+// the priors are added to the counters of the banners and the reward is scaled by the weight of the banner.
+// Returns the probability the banner was selected with, zero when the strategy does not know it
+func (b *RotationService) defineBanner(
+	rotations []*repository.Rotation,
+	countersList []*repository.Counters,
+	priors map[int]repository.Banner,
+	slot repository.Slot,
+	now time.Time,
+) (*repository.Rotation, float64, error) {
+	if len(rotations) <= 0 {
+		return nil, 0, ErrRotationsListEmpty
+	}
+
 	banners := make(map[int]repository.Banner, len(rotations))
+	weights := make(map[int]float64, len(rotations))
 	for _, rotation := range rotations {
 		banners[rotation.BannerID] = repository.Banner{ID: rotation.BannerID}
+		weights[rotation.BannerID] = rotation.RewardWeight()
 	}
 
-	for _, statistics := range statisticsList {
-		banner, has := banners[statistics.BannerID]
+	discount := b.Strategies.Discount(slot.Strategy)
+	weighted := b.Strategies.IsWeighted(slot.Strategy)
+
+	examination, err := b.examination(countersList)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, counters := range countersList {
+		banner, has := banners[counters.BannerID]
 
 		if !has {
 			continue
 		}
 
-		if statistics.IsTypeView() {
-			banner.Views++
+		weight := 1.0
+		if discount != nil {
+			weight = discount(now.Sub(counters.Period))
 		}
 
-		if statistics.IsTypeClick() {
-			banner.Clicks++
+		views := float64(counters.Views)
+		if examination != nil {
+			views *= examination(counters.Position)
 		}
 
-		banner.GroupID = statistics.GroupID
+		clicks := float64(counters.Clicks)
+		conversions := b.conversionReward(counters.Value, counters.Conversions)
+		if weighted {
+			clicks = counters.Estimate(clicks)
+			conversions = counters.Estimate(conversions)
+		}
+
+		banner.Views += weight * views
+		banner.Clicks += weight * clicks
+		banner.Conversions += weight * conversions
+		banner.GroupID = counters.GroupID
 
 		banners[banner.ID] = banner
 	}
 
-	selected := make([]int, 0, len(banners))
+	for bannerID, prior := range priors {
+		banner, has := banners[bannerID]
+
+		if !has {
+			continue
+		}
+
+		banner.Views += prior.Views
+		banner.Clicks += prior.Clicks
+		banner.Conversions += prior.Conversions
+
+		banners[bannerID] = banner
+	}
+
+	// the arms are ordered by the banner id, so the selection only depends on the random source
+	bannerIDs := make([]int, 0, len(banners))
+	for bannerID := range banners {
+		bannerIDs = append(bannerIDs, bannerID)
+	}
+
+	sort.Ints(bannerIDs)
+
+	selected := make([]float64, 0, len(banners))
 	reward := make([]float64, 0, len(banners))
 	arms := make(map[int]int, len(banners))
-	i := 0
 
-	for bannerID, banner := range banners {
+	for i, bannerID := range bannerIDs {
 		arms[i] = bannerID
-		selected = append(selected, banner.Views)
-		reward = append(reward, banner.Clicks)
-		i++
+		selected = append(selected, banners[bannerID].Views)
+		if slot.Reward == repository.RewardConversions {
+			reward = append(reward, weights[bannerID]*banners[bannerID].Conversions)
+		} else {
+			reward = append(reward, weights[bannerID]*banners[bannerID].Clicks)
+		}
 	}
 
-	ucb1, err := algorithm.NewUCB1(selected, reward)
+	a, err := b.Strategies.New(slot.Strategy, selected, reward, algorithm.Parameters(slot.Parameters))
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	arm := ucb1.SelectArm()
+	arm := a.SelectArm()
 	bannerID := arms[arm]
 	rotation := new(repository.Rotation)
 
@@ -142,5 +820,100 @@ func (b *RotationService) defineBanner(
 		}
 	}
 
-	return rotation, nil
+	probability := 0.0
+	if p, isProbabilistic := a.(algorithm.Probabilistic); isProbabilistic {
+		probability = p.Probabilities()[arm]
+	}
+
+	return rotation, probability, nil
+}
+
+// Returns the examination of the positions by the click model, nil when the views are not debiased,
+// the cascade model takes the probability of the click from the counters of the slot
+func (b *RotationService) examination(countersList []*repository.Counters) (algorithm.Examination, error) {
+	views, clicks := 0, 0
+	for _, counters := range countersList {
+		views += counters.Views
+		clicks += counters.Clicks
+	}
+
+	ctr := 0.0
+	if views > 0 {
+		ctr = float64(clicks) / float64(views)
+	}
+
+	return algorithm.NewExamination(b.ClickModel, b.PositionBias, ctr)
+}
+
+// Determines which banner should be displayed for the request attributes by the contextual strategy,
+// the model of every banner is learned from the attributes stored with its statistics,
+// the rewards are scaled by the weight of the banner
+func (b *RotationService) defineBannerByContext(
+	rotations []*repository.Rotation,
+	statisticsList []*repository.Statistics,
+	slot repository.Slot,
+	attributes repository.Attributes,
+) (*repository.Rotation, error) {
+	if len(rotations) <= 0 {
+		return nil, ErrRotationsListEmpty
+	}
+
+	sort.Slice(rotations, func(i, j int) bool {
+		return rotations[i].BannerID < rotations[j].BannerID
+	})
+
+	arms := make(map[int]int, len(rotations))
+	for i, rotation := range rotations {
+		arms[rotation.BannerID] = i
+	}
+
+	a, err := b.Strategies.NewContextual(slot.Strategy, len(rotations), algorithm.Parameters(slot.Parameters))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statistics := range statisticsList {
+		arm, has := arms[statistics.BannerID]
+
+		if !has {
+			continue
+		}
+
+		features := algorithm.HashFeatures(statistics.Attributes, a.Dimension())
+
+		if statistics.IsTypeView() {
+			err = a.Observe(arm, features, 1, 0)
+		}
+
+		weight := rotations[arm].RewardWeight()
+
+		if statistics.IsTypeClick() && slot.Reward != repository.RewardConversions {
+			err = a.Observe(arm, features, 0, weight)
+		}
+
+		if statistics.IsTypeConversion() && slot.Reward == repository.RewardConversions {
+			err = a.Observe(arm, features, 0, weight*b.conversionReward(statistics.Value, 1))
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = a.SetContext(algorithm.HashFeatures(attributes, a.Dimension()))
+	if err != nil {
+		return nil, err
+	}
+
+	return rotations[a.SelectArm()], nil
+}
+
+// Returns the reward of the total value of the conversions scaled by the max conversion value,
+// the reward of every conversion is at most one
+func (b *RotationService) conversionReward(value float64, conversions int) float64 {
+	if b.MaxConversionValue <= 0 {
+		return value
+	}
+
+	return math.Min(value/b.MaxConversionValue, float64(conversions))
 }
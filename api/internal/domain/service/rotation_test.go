@@ -2,13 +2,39 @@ package service
 
 import (
 	"context"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/config"
 	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/koind/banner-rotation/api/internal/storage/cache"
 	"github.com/koind/banner-rotation/api/internal/storage/memory"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"sync"
 	"testing"
 	"time"
 )
 
+// Returns the rotation service over the memory repositories with the default strategies seeded with one
+func newTestRotationService(t *testing.T) *RotationService {
+	t.Helper()
+
+	statisticsRepository := memory.NewStatisticsRepository()
+	countersRepository := memory.NewCountersRepository()
+
+	return &RotationService{
+		RotationRepository: memory.NewRotationRepository(),
+		StatisticsService: &StatisticsService{
+			StatisticsRepository: statisticsRepository,
+			CountersRepository:   countersRepository,
+		},
+		StatisticsRepository: statisticsRepository,
+		CountersRepository:   countersRepository,
+		SlotRepository:       memory.NewSlotRepository(),
+		Strategies:           algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies()),
+	}
+}
+
 func TestRotationService_Add(t *testing.T) {
 	rotationService := RotationService{
 		RotationRepository: memory.NewRotationRepository(),
@@ -28,10 +54,12 @@ func TestRotationService_Add(t *testing.T) {
 
 func TestRotationService_SetTransition(t *testing.T) {
 	statisticsRepository := memory.NewStatisticsRepository()
+	countersRepository := memory.NewCountersRepository()
 	rotationService := RotationService{
 		RotationRepository: memory.NewRotationRepository(),
 		StatisticsService: &StatisticsService{
 			StatisticsRepository: statisticsRepository,
+			CountersRepository:   countersRepository,
 		},
 		StatisticsRepository: statisticsRepository,
 	}
@@ -54,7 +82,7 @@ func TestRotationService_SetTransition(t *testing.T) {
 		GroupID:  8,
 	}
 
-	statistics, err := rotationService.SetTransition(context.Background(), rotation, groupID)
+	statistics, err := rotationService.SetTransition(context.Background(), rotation, groupID, nil)
 	assert.Nil(t, err)
 
 	expectedStatistics.CreatedAt = statistics.CreatedAt
@@ -346,16 +374,27 @@ func TestRotationService_SelectBanner(t *testing.T) {
 		},
 	}
 
-	for _, testCase := range testCases {
+	for i := range testCases {
+		testCase := &testCases[i]
+		countersRepository := memory.NewCountersRepository()
+
+		for _, statistics := range testCase.statisticsRepository.DB {
+			countersRepository.Add(context.Background(), repository.NewCounters(statistics))
+		}
+
 		rotationService := RotationService{
 			RotationRepository: &testCase.rotationRepository,
 			StatisticsService: &StatisticsService{
 				StatisticsRepository: &testCase.statisticsRepository,
+				CountersRepository:   countersRepository,
 			},
 			StatisticsRepository: &testCase.statisticsRepository,
+			CountersRepository:   countersRepository,
+			SlotRepository:       memory.NewSlotRepository(),
+			Strategies:           algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies()),
 		}
 
-		bannerID, _, err := rotationService.SelectBanner(context.Background(), testCase.slotID, testCase.groupID)
+		bannerID, _, err := rotationService.SelectBanner(context.Background(), testCase.slotID, testCase.groupID, "", nil)
 
 		if err != nil {
 			assert.Error(t, testCase.err, &err)
@@ -364,3 +403,904 @@ func TestRotationService_SelectBanner(t *testing.T) {
 		}
 	}
 }
+
+func TestRotationService_SetStrategy(t *testing.T) {
+	rotationService := RotationService{
+		SlotRepository: memory.NewSlotRepository(),
+		Strategies:     algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies()),
+	}
+
+	slot, err := rotationService.SetStrategy(context.Background(), 1, algorithm.StrategyThompson, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &repository.Slot{ID: 1, Strategy: algorithm.StrategyThompson}, slot)
+
+	_, err = rotationService.SetStrategy(context.Background(), 1, "unknown", nil)
+	assert.Equal(t, algorithm.ErrUnknownStrategy, err)
+
+	_, err = rotationService.SetStrategy(context.Background(), 1, algorithm.StrategyEXP3, repository.Parameters{"gamma": 2})
+	assert.NotNil(t, err, "gamma greater than one should be rejected")
+
+	slot, err = rotationService.slot(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, algorithm.StrategyThompson, slot.Strategy)
+
+	slot, err = rotationService.slot(context.Background(), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, algorithm.DefaultStrategy, slot.Strategy, "slot without settings should use the default strategy")
+
+	parameters := repository.Parameters{"gamma": 0.5}
+	slot, err = rotationService.SetStrategy(context.Background(), 3, algorithm.StrategyEXP3, parameters)
+	assert.Nil(t, err)
+	assert.Equal(t, &repository.Slot{ID: 3, Strategy: algorithm.StrategyEXP3, Parameters: parameters}, slot)
+}
+
+func TestRotationService_SelectBannerRecordsStrategy(t *testing.T) {
+	rotationService := newTestRotationService(t)
+
+	ctx := context.Background()
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	for _, strategy := range rotationService.Strategies.Names() {
+		_, err := rotationService.SetStrategy(ctx, 1, strategy, nil)
+		assert.Nil(t, err)
+
+		bannerID, statistics, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+		assert.Nil(t, err)
+		assert.Contains(t, []int{1, 2}, bannerID)
+		assert.Equal(t, strategy, statistics.Strategy, "view should record the strategy")
+		assert.Equal(t, repository.StatisticsTypeView, statistics.Type)
+
+		if strategy == algorithm.StrategyEXP3 {
+			assert.True(t, statistics.Probability > 0, "view should record the probability of the banner")
+		}
+	}
+}
+
+func TestRotationService_SelectBannerByEstimatedRewards(t *testing.T) {
+	rotationService := RotationService{
+		Strategies: algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies()),
+	}
+
+	now := time.Now().UTC()
+	rotations := []*repository.Rotation{
+		{BannerID: 1, SlotID: 1},
+		{BannerID: 2, SlotID: 1},
+	}
+	// the same clicks, but the views of the second banner were selected with the lower probability
+	countersList := []*repository.Counters{
+		{SlotID: 1, GroupID: 1, BannerID: 1, Views: 100, Clicks: 10, InverseProbability: 100 / 0.5},
+		{SlotID: 1, GroupID: 1, BannerID: 2, Views: 100, Clicks: 10, InverseProbability: 100 / 0.1},
+	}
+	slot := repository.Slot{ID: 1, Strategy: algorithm.StrategyEXP3, Reward: repository.RewardClicks}
+
+	selected := make(map[int]int)
+	for i := 0; i < 100; i++ {
+		rotation, probability, err := rotationService.defineBanner(rotations, countersList, nil, slot, now)
+		assert.Nil(t, err)
+		assert.True(t, probability > 0 && probability < 1, "probability of the selected banner should be returned")
+
+		selected[rotation.BannerID]++
+	}
+
+	assert.True(t, selected[2] > 80, "banner with the bigger estimated reward should get the most of the traffic")
+}
+
+func TestRotationService_SelectBannerForgetsOldStatistics(t *testing.T) {
+	rotationService := newTestRotationService(t)
+	countersRepository := rotationService.CountersRepository.(*memory.CountersRepository)
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	monthAgo := now.AddDate(0, -1, 0)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	add := func(bannerID int, statisticsType int, createdAt time.Time, n int) {
+		for i := 0; i < n; i++ {
+			countersRepository.Add(ctx, repository.NewCounters(repository.Statistics{
+				Type:      statisticsType,
+				BannerID:  bannerID,
+				SlotID:    1,
+				GroupID:   1,
+				CreatedAt: createdAt,
+			}))
+		}
+	}
+
+	add(1, repository.StatisticsTypeView, monthAgo, 1000)
+	add(1, repository.StatisticsTypeClick, monthAgo, 500)
+	add(1, repository.StatisticsTypeView, now, 100)
+	add(2, repository.StatisticsTypeView, now, 1000)
+	add(2, repository.StatisticsTypeClick, now, 300)
+
+	testCases := []struct {
+		strategy         string
+		expectedBannerID int
+	}{
+		{algorithm.StrategyUCB1, 1},
+		{algorithm.StrategySlidingWindowUCB, 2},
+		{algorithm.StrategyDiscountedUCB, 2},
+	}
+
+	for _, testCase := range testCases {
+		rotations, _ := rotationService.RotationRepository.FindAllBySlotID(ctx, 1)
+		countersList, _ := countersRepository.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+
+		slot := repository.Slot{ID: 1, Strategy: testCase.strategy}
+
+		rotation, _, err := rotationService.defineBanner(rotations, countersList, nil, slot, now)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedBannerID, rotation.BannerID, testCase.strategy)
+	}
+}
+
+func TestRotationService_SelectBannerByContext(t *testing.T) {
+	rotationService := newTestRotationService(t)
+
+	ctx := context.Background()
+	mobile := repository.Attributes{"device": "mobile"}
+	desktop := repository.Attributes{"device": "desktop"}
+
+	_, err := rotationService.SetStrategy(ctx, 1, algorithm.StrategyLinUCB, nil)
+	assert.Nil(t, err)
+
+	mobileRotation, _ := rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	desktopRotation, _ := rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	for i := 0; i < 50; i++ {
+		for _, attributes := range []repository.Attributes{mobile, desktop} {
+			bannerID, statistics, err := rotationService.SelectBanner(ctx, 1, 1, "", attributes)
+			assert.Nil(t, err)
+			assert.Equal(t, attributes, statistics.Attributes, "view should record the attributes")
+
+			if bannerID == mobileRotation.BannerID && attributes["device"] == "mobile" {
+				rotationService.SetTransition(ctx, *mobileRotation, 1, attributes)
+			}
+			if bannerID == desktopRotation.BannerID && attributes["device"] == "desktop" {
+				rotationService.SetTransition(ctx, *desktopRotation, 1, attributes)
+			}
+		}
+	}
+
+	bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", mobile)
+	assert.Nil(t, err)
+	assert.Equal(t, mobileRotation.BannerID, bannerID, "should select the banner clicked on mobile")
+
+	bannerID, _, err = rotationService.SelectBanner(ctx, 1, 1, "", desktop)
+	assert.Nil(t, err)
+	assert.Equal(t, desktopRotation.BannerID, bannerID, "should select the banner clicked on desktop")
+}
+
+func TestRotationService_SelectBannerReproducible(t *testing.T) {
+	sequence := func(seed int64) []int {
+		rotationService := newTestRotationService(t)
+		rotationService.Strategies = algorithm.NewDefaultRegistry(algorithm.NewLockedSource(seed), config.DefaultStrategies())
+
+		ctx := context.Background()
+		for bannerID := 1; bannerID <= 5; bannerID++ {
+			rotationService.Add(ctx, repository.Rotation{BannerID: bannerID, SlotID: 1, Description: "Banner"})
+		}
+
+		bannerIDs := make([]int, 0, 5)
+		for i := 0; i < 5; i++ {
+			bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+			assert.Nil(t, err)
+
+			bannerIDs = append(bannerIDs, bannerID)
+		}
+
+		return bannerIDs
+	}
+
+	assert.Equal(t, sequence(3), sequence(3), "the same seed should select the same banners")
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, sequence(3), "cold start should show every banner once")
+}
+
+func TestRotationService_SetConversion(t *testing.T) {
+	statisticsRepository := memory.NewStatisticsRepository()
+	countersRepository := memory.NewCountersRepository()
+	rotationService := RotationService{
+		StatisticsService: &StatisticsService{
+			StatisticsRepository: statisticsRepository,
+			CountersRepository:   countersRepository,
+		},
+		SlotRepository: memory.NewSlotRepository(),
+		Strategies:     algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies()),
+	}
+
+	ctx := context.Background()
+	rotation := repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"}
+
+	statistics, err := rotationService.SetConversion(ctx, rotation, 2, 19.99, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.StatisticsTypeConversion, statistics.Type)
+	assert.Equal(t, 19.99, statistics.Value)
+	assert.Equal(t, 2, statistics.GroupID)
+
+	_, err = rotationService.SetConversion(ctx, rotation, 2, -1, nil)
+	assert.Equal(t, ErrInvalidConversionValue, err)
+
+	slot, err := rotationService.SetReward(ctx, 1, repository.RewardConversions)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.RewardConversions, slot.Reward)
+
+	_, err = rotationService.SetReward(ctx, 1, "unknown")
+	assert.Equal(t, ErrUnknownReward, err)
+
+	slot, err = rotationService.slot(ctx, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.RewardClicks, slot.Reward, "slot without settings should maximize the clicks")
+}
+
+func TestRotationService_SelectBannerByConversions(t *testing.T) {
+	rotationService := newTestRotationService(t)
+	rotationService.MaxConversionValue = 100
+	countersRepository := rotationService.CountersRepository.(*memory.CountersRepository)
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Cheap clicks"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Purchases"})
+
+	add := func(bannerID int, statisticsType int, value float64, n int) {
+		for i := 0; i < n; i++ {
+			countersRepository.Add(ctx, repository.NewCounters(repository.Statistics{
+				Type:      statisticsType,
+				BannerID:  bannerID,
+				SlotID:    1,
+				GroupID:   1,
+				Value:     value,
+				CreatedAt: now,
+			}))
+		}
+	}
+
+	add(1, repository.StatisticsTypeView, 0, 1000)
+	add(1, repository.StatisticsTypeClick, 0, 300)
+	add(2, repository.StatisticsTypeView, 0, 1000)
+	add(2, repository.StatisticsTypeClick, 0, 10)
+	add(2, repository.StatisticsTypeConversion, 20, 5)
+
+	testCases := []struct {
+		reward           string
+		expectedBannerID int
+	}{
+		{repository.RewardClicks, 1},
+		{repository.RewardConversions, 2},
+	}
+
+	for _, testCase := range testCases {
+		rotations, _ := rotationService.RotationRepository.FindAllBySlotID(ctx, 1)
+		countersList, _ := countersRepository.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+		slot := repository.Slot{ID: 1, Strategy: algorithm.StrategyUCB1Tuned, Reward: testCase.reward}
+
+		rotation, _, err := rotationService.defineBanner(rotations, countersList, nil, slot, now)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedBannerID, rotation.BannerID, testCase.reward)
+	}
+}
+
+func TestRotationService_AddWithPrior(t *testing.T) {
+	rotationService := RotationService{
+		RotationRepository: memory.NewRotationRepository(),
+	}
+
+	testCases := []struct {
+		name     string
+		rotation repository.Rotation
+		err      error
+	}{
+		{"fixed prior", repository.Rotation{BannerID: 1, Prior: repository.PriorFixed, PriorViews: 100, PriorClicks: 2}, nil},
+		{"slot prior", repository.Rotation{BannerID: 2, Prior: repository.PriorSlot, PriorViews: 100}, nil},
+		{"unknown prior", repository.Rotation{BannerID: 3, Prior: "unknown"}, repository.ErrUnknownPrior},
+		{"negative views", repository.Rotation{BannerID: 4, Prior: repository.PriorFixed, PriorViews: -1}, repository.ErrInvalidPrior},
+	}
+
+	for _, testCase := range testCases {
+		_, err := rotationService.Add(context.Background(), testCase.rotation)
+		assert.Equal(t, testCase.err, err, testCase.name)
+	}
+}
+
+func TestRotationService_SelectBannerWithPrior(t *testing.T) {
+	rotationService := newTestRotationService(t)
+	countersRepository := rotationService.CountersRepository.(*memory.CountersRepository)
+
+	ctx := context.Background()
+
+	add := func(bannerID int, slotID int, statisticsType int, n int) {
+		for i := 0; i < n; i++ {
+			countersRepository.Add(ctx, repository.NewCounters(repository.Statistics{
+				Type:      statisticsType,
+				BannerID:  bannerID,
+				SlotID:    slotID,
+				GroupID:   1,
+				CreatedAt: time.Now().UTC(),
+			}))
+		}
+	}
+
+	add(1, 1, repository.StatisticsTypeView, 100)
+	add(1, 1, repository.StatisticsTypeClick, 50)
+	add(2, 1, repository.StatisticsTypeView, 100)
+	add(2, 1, repository.StatisticsTypeClick, 10)
+	add(4, 2, repository.StatisticsTypeView, 100)
+	add(4, 2, repository.StatisticsTypeClick, 40)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+	rotationService.Add(ctx, repository.Rotation{
+		BannerID:    3,
+		SlotID:      1,
+		Description: "Slot prior",
+		Prior:       repository.PriorSlot,
+		PriorViews:  20,
+	})
+	rotationService.Add(ctx, repository.Rotation{
+		BannerID:    4,
+		SlotID:      1,
+		Description: "Banner prior",
+		Prior:       repository.PriorBanner,
+		PriorViews:  10,
+	})
+	rotationService.Add(ctx, repository.Rotation{
+		BannerID:    5,
+		SlotID:      1,
+		Description: "Fixed prior",
+		Prior:       repository.PriorFixed,
+		PriorViews:  100,
+		PriorClicks: 1,
+	})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 6, SlotID: 1, Description: "No history", Prior: repository.PriorBanner})
+
+	rotations, _ := rotationService.RotationRepository.FindAllBySlotID(ctx, 1)
+	countersList, _ := countersRepository.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	slot := repository.Slot{ID: 1, Strategy: algorithm.StrategyUCB1, Reward: repository.RewardClicks}
+
+	priors, err := rotationService.priors(ctx, rotations, countersList, slot, 1)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		map[int]repository.Banner{
+			3: {ID: 3, Views: 20, Clicks: 6, Conversions: 6},
+			4: {ID: 4, Views: 10, Clicks: 4, Conversions: 4},
+			5: {ID: 5, Views: 100, Clicks: 1, Conversions: 1},
+		},
+		priors,
+		"slot average is 60 clicks per 200 views, banner history is 40 clicks per 100 views",
+	)
+
+	rotations = rotations[:0]
+	for _, bannerID := range []int{1, 5} {
+		rotation, _ := rotationService.RotationRepository.FindOneByBannerID(ctx, bannerID)
+		rotations = append(rotations, rotation)
+	}
+
+	rotation, _, err := rotationService.defineBanner(rotations, countersList, priors, slot, time.Now().UTC())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, rotation.BannerID, "banner with a poor prior should not be forced to show")
+
+	rotation, _, err = rotationService.defineBanner(rotations, countersList, nil, slot, time.Now().UTC())
+	assert.Nil(t, err)
+	assert.Equal(t, 5, rotation.BannerID, "banner without views should be shown first")
+}
+
+func TestRotationService_SelectBannerWithCache(t *testing.T) {
+	statisticsRepository := memory.NewStatisticsRepository()
+	countersRepository := memory.NewCountersRepository()
+	c := cache.New(
+		cache.Options{FlushInterval: time.Hour, FlushSize: 1000},
+		statisticsRepository,
+		countersRepository,
+		memory.NewRotationRepository(),
+		memory.NewSlotRepository(),
+		nil,
+		*zap.NewNop(),
+	)
+	rotationService := RotationService{
+		RotationRepository: c.Rotations,
+		StatisticsService: &StatisticsService{
+			StatisticsRepository: c.Statistics,
+			CountersRepository:   c.Counters,
+		},
+		StatisticsRepository: c.Statistics,
+		CountersRepository:   c.Counters,
+		SlotRepository:       c.Slots,
+		Strategies:           algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies()),
+	}
+
+	ctx := context.Background()
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	shown := make(map[int]int)
+	for i := 0; i < 10; i++ {
+		bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+		assert.Nil(t, err)
+		shown[bannerID]++
+	}
+
+	assert.Equal(t, 5, shown[1], "cached views should be taken into account by the next selection")
+	assert.Equal(t, 5, shown[2], "cached views should be taken into account by the next selection")
+	assert.Empty(t, countersRepository.DB, "views should not be written before the flush")
+	assert.Empty(t, statisticsRepository.DB, "views should not be written before the flush")
+
+	assert.Nil(t, c.Flush(ctx))
+
+	countersList, err := countersRepository.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+
+	views := 0
+	for _, counters := range countersList {
+		views += counters.Views
+	}
+
+	assert.Equal(t, 10, views)
+	assert.Len(t, statisticsRepository.DB, 10)
+
+	bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+	assert.Nil(t, err)
+	assert.Contains(t, []int{1, 2}, bannerID)
+	assert.Nil(t, c.Close(ctx), "close should flush the pending views")
+	assert.Len(t, statisticsRepository.DB, 11)
+}
+
+func TestRotationService_SelectBannerWhileFlushing(t *testing.T) {
+	countersRepository := memory.NewCountersRepository()
+	c := cache.New(
+		cache.Options{FlushInterval: time.Hour},
+		memory.NewStatisticsRepository(),
+		countersRepository,
+		memory.NewRotationRepository(),
+		memory.NewSlotRepository(),
+		nil,
+		*zap.NewNop(),
+	)
+	rotationService := RotationService{
+		RotationRepository: c.Rotations,
+		StatisticsService: &StatisticsService{
+			StatisticsRepository: c.Statistics,
+			CountersRepository:   c.Counters,
+		},
+		StatisticsRepository: c.Statistics,
+		CountersRepository:   c.Counters,
+		SlotRepository:       c.Slots,
+		Strategies:           algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies()),
+	}
+
+	ctx := context.Background()
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				rotationService.SelectBanner(ctx, 1, 1, "", nil)
+				c.Counters.FindAllBySlotIDSince(ctx, 1, time.Time{})
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		assert.Nil(t, c.Flush(ctx))
+	}
+
+	wg.Wait()
+	assert.Nil(t, c.Flush(ctx))
+
+	countersList, err := c.Counters.FindAllBySlotIDSince(ctx, 1, time.Time{})
+	assert.Nil(t, err)
+
+	views := 0
+	for _, counters := range countersList {
+		views += counters.Views
+	}
+
+	assert.Equal(t, 200, views, "every view should be counted once")
+	assert.Len(t, countersRepository.DB, 2)
+}
+
+func TestRotationService_SelectBanners(t *testing.T) {
+	rotationService := newTestRotationService(t)
+	statisticsRepository := rotationService.StatisticsRepository.(*memory.StatisticsRepository)
+	countersRepository := rotationService.CountersRepository.(*memory.CountersRepository)
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	for bannerID, clicks := range map[int]int{1: 10, 2: 90, 3: 50, 4: 30} {
+		rotationService.Add(ctx, repository.Rotation{BannerID: bannerID, SlotID: 1, Description: "Banner"})
+		countersRepository.Add(ctx, repository.Counters{
+			SlotID:   1,
+			GroupID:  1,
+			BannerID: bannerID,
+			Period:   now.Truncate(repository.CountersPeriod),
+			Views:    1000,
+			Clicks:   clicks,
+		})
+	}
+
+	bannerIDs, statisticsList, err := rotationService.SelectBanners(ctx, 1, 1, "", 3, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3, 4}, bannerIDs, "banners should be ranked by the strategy")
+	assert.Len(t, statisticsList, 3)
+	assert.Len(t, statisticsRepository.DB, 3, "view should be recorded for every banner")
+
+	for i, statistics := range statisticsList {
+		assert.Equal(t, bannerIDs[i], statistics.BannerID)
+		assert.Equal(t, repository.StatisticsTypeView, statistics.Type)
+	}
+
+	bannerIDs, _, err = rotationService.SelectBanners(ctx, 1, 1, "", 10, nil)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, bannerIDs, "all the banners of the slot should be returned at most")
+
+	_, _, err = rotationService.SelectBanners(ctx, 1, 1, "", 0, nil)
+	assert.Equal(t, ErrInvalidCount, err)
+
+	for _, strategy := range rotationService.Strategies.Names() {
+		_, err := rotationService.SetStrategy(ctx, 1, strategy, nil)
+		assert.Nil(t, err)
+
+		bannerIDs, _, err := rotationService.SelectBanners(ctx, 1, 1, "", 4, nil)
+		assert.Nil(t, err, strategy)
+		assert.ElementsMatch(t, []int{1, 2, 3, 4}, bannerIDs, "banners should be distinct for "+strategy)
+	}
+}
+
+func TestRotationService_SelectBannerByPosition(t *testing.T) {
+	rotationService := RotationService{
+		Strategies: algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies()),
+	}
+
+	now := time.Now().UTC()
+	period := now.Truncate(repository.CountersPeriod)
+	rotations := []*repository.Rotation{
+		{BannerID: 1, SlotID: 1},
+		{BannerID: 2, SlotID: 1},
+	}
+	countersList := []*repository.Counters{
+		{SlotID: 1, GroupID: 1, BannerID: 1, Period: period, Position: 2, Views: 1000},
+		{SlotID: 1, GroupID: 1, BannerID: 1, Period: period, Clicks: 50},
+		{SlotID: 1, GroupID: 1, BannerID: 2, Period: period, Position: 1, Views: 1000},
+		{SlotID: 1, GroupID: 1, BannerID: 2, Period: period, Clicks: 80},
+	}
+	slot := repository.Slot{
+		ID:         1,
+		Strategy:   algorithm.StrategyEpsilonGreedy,
+		Parameters: repository.Parameters{algorithm.ParameterEpsilon: 0},
+		Reward:     repository.RewardClicks,
+	}
+
+	rotation, _, err := rotationService.defineBanner(rotations, countersList, nil, slot, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rotation.BannerID, "banner on the top position should win without the click model")
+
+	rotationService.ClickModel = algorithm.ClickModelPosition
+	rotationService.PositionBias = []float64{1, 0.5}
+
+	rotation, _, err = rotationService.defineBanner(rotations, countersList, nil, slot, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, rotation.BannerID, "half of the views on the second position should not be examined")
+
+	rotationService.ClickModel = algorithm.ClickModelCascade
+
+	rotation, _, err = rotationService.defineBanner(rotations, countersList, nil, slot, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rotation.BannerID, "cascade model should only discount the views by the chance of a click above")
+
+	rotationService.ClickModel = "unknown"
+
+	_, _, err = rotationService.defineBanner(rotations, countersList, nil, slot, now)
+	assert.Equal(t, algorithm.ErrUnknownClickModel, err)
+}
+
+func TestRotationService_SelectBannersRecordsPositions(t *testing.T) {
+	rotationService := newTestRotationService(t)
+	countersRepository := rotationService.CountersRepository.(*memory.CountersRepository)
+
+	ctx := context.Background()
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	_, statisticsList, err := rotationService.SelectBanners(ctx, 1, 1, "", 2, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, statisticsList[0].Position)
+	assert.Equal(t, 2, statisticsList[1].Position)
+
+	countersList, err := countersRepository.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Len(t, countersList, 2)
+
+	for _, counters := range countersList {
+		for _, statistics := range statisticsList {
+			if statistics.BannerID == counters.BannerID {
+				assert.Equal(t, statistics.Position, counters.Position, "counters should be kept per position")
+			}
+		}
+	}
+}
+
+func TestRotationService_SetSchedule(t *testing.T) {
+	rotationService := RotationService{
+		RotationRepository: memory.NewRotationRepository(),
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	startsAt, endsAt := now.Add(time.Hour), now.Add(2*time.Hour)
+
+	_, err := rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, StartsAt: &endsAt, EndsAt: &startsAt})
+	assert.Equal(t, repository.ErrInvalidSchedule, err)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+
+	rotation, err := rotationService.SetSchedule(ctx, 1, &startsAt, &endsAt)
+	assert.Nil(t, err)
+	assert.Equal(t, &startsAt, rotation.StartsAt)
+	assert.Equal(t, &endsAt, rotation.EndsAt)
+	assert.Equal(t, "Banner 1", rotation.Description, "schedule should keep the other settings of the rotation")
+
+	rotation, err = rotationService.SetSchedule(ctx, 1, nil, &endsAt)
+	assert.Nil(t, err)
+	assert.Nil(t, rotation.StartsAt)
+
+	_, err = rotationService.SetSchedule(ctx, 1, &endsAt, &startsAt)
+	assert.Equal(t, repository.ErrInvalidSchedule, err)
+
+	_, err = rotationService.SetSchedule(ctx, 2, nil, nil)
+	assert.Equal(t, ErrRotationNotFound, err)
+}
+
+func TestRotationService_SelectBannerBySchedule(t *testing.T) {
+	rotationService := newTestRotationService(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Running", StartsAt: &past, EndsAt: &future})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Ended", EndsAt: &past})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 3, SlotID: 1, Description: "Upcoming", StartsAt: &future})
+
+	for i := 0; i < 5; i++ {
+		bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, bannerID, "only the running banner should be selected")
+	}
+
+	bannerIDs, _, err := rotationService.SelectBanners(ctx, 1, 1, "", 3, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, bannerIDs)
+
+	rotationService.SetSchedule(ctx, 1, nil, &past)
+
+	_, _, err = rotationService.SelectBanner(ctx, 1, 1, "", nil)
+	assert.Equal(t, ErrRotationsListEmpty, errors.Cause(err), "slot without running banners should have nothing to select")
+}
+
+func TestRotationService_SelectBannerByDayparting(t *testing.T) {
+	rotationService := RotationService{
+		Location: time.FixedZone("UTC+6", 6*60*60),
+	}
+
+	rotations := []*repository.Rotation{
+		{BannerID: 1, Description: "Always"},
+		{BannerID: 2, Description: "Working hours", Dayparting: repository.Dayparting{{From: 9, To: 18}}},
+		{BannerID: 3, Description: "Nights", Dayparting: repository.Dayparting{{From: 22, To: 6}}},
+		{
+			BannerID:    4,
+			Description: "Weekends in UTC",
+			Timezone:    "UTC",
+			Dayparting:  repository.Dayparting{{Weekdays: []time.Weekday{time.Saturday, time.Sunday}}},
+		},
+	}
+
+	bannerIDs := func(at time.Time) []int {
+		scheduled, err := rotationService.selectable(rotations, at)
+		assert.Nil(t, err)
+
+		ids := make([]int, 0, len(scheduled))
+		for _, rotation := range scheduled {
+			ids = append(ids, rotation.BannerID)
+		}
+
+		return ids
+	}
+
+	// Friday 2019-11-22 04:00 UTC is 10:00 in the configured timezone
+	assert.Equal(t, []int{1, 2}, bannerIDs(time.Date(2019, 11, 22, 4, 0, 0, 0, time.UTC)))
+
+	// Friday 2019-11-22 20:00 UTC is 02:00 on Saturday in the configured timezone, but Friday in UTC
+	assert.Equal(t, []int{1, 3}, bannerIDs(time.Date(2019, 11, 22, 20, 0, 0, 0, time.UTC)))
+
+	// Saturday 2019-11-23 12:00 UTC is 18:00 in the configured timezone
+	assert.Equal(t, []int{1, 4}, bannerIDs(time.Date(2019, 11, 23, 12, 0, 0, 0, time.UTC)))
+
+	rotations = append(rotations, &repository.Rotation{
+		BannerID:   5,
+		Timezone:   "Unknown/Timezone",
+		Dayparting: repository.Dayparting{{From: 1, To: 2}},
+	})
+
+	_, err := rotationService.selectable(rotations, time.Now())
+	assert.NotNil(t, err)
+}
+
+func TestRotationService_SetDayparting(t *testing.T) {
+	rotationService := newTestRotationService(t)
+	countersRepository := rotationService.CountersRepository.(*memory.CountersRepository)
+
+	ctx := context.Background()
+	hour := time.Now().UTC().Hour()
+	offSchedule := repository.Dayparting{{From: (hour + 1) % 24, To: (hour + 2) % 24}}
+
+	_, err := rotationService.Add(ctx, repository.Rotation{
+		BannerID:   1,
+		SlotID:     1,
+		Dayparting: repository.Dayparting{{From: 0, To: 25}},
+	})
+	assert.Equal(t, repository.ErrInvalidDayparting, err)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	rotation, err := rotationService.SetDayparting(ctx, 2, "", offSchedule)
+	assert.Nil(t, err)
+	assert.Equal(t, offSchedule, rotation.Dayparting)
+
+	for i := 0; i < 5; i++ {
+		bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, bannerID, "banner out of its hours should not be selected")
+	}
+
+	countersList, _ := countersRepository.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	for _, counters := range countersList {
+		assert.NotEqual(t, 2, counters.BannerID, "banner out of its hours should keep its statistics")
+	}
+
+	_, err = rotationService.SetDayparting(ctx, 2, "Unknown/Timezone", offSchedule)
+	assert.NotNil(t, err)
+
+	_, err = rotationService.SetDayparting(ctx, 2, "", repository.Dayparting{{Weekdays: []time.Weekday{7}}})
+	assert.Equal(t, repository.ErrInvalidDayparting, err)
+
+	_, err = rotationService.SetDayparting(ctx, 3, "", nil)
+	assert.Equal(t, ErrRotationNotFound, err)
+}
+
+func TestRotationService_PauseAndResume(t *testing.T) {
+	rotationService := newTestRotationService(t)
+	countersRepository := rotationService.CountersRepository.(*memory.CountersRepository)
+
+	ctx := context.Background()
+	_, err := rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Status: "unknown"})
+	assert.Equal(t, repository.ErrUnknownStatus, err)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	for i := 0; i < 4; i++ {
+		rotationService.SelectBanner(ctx, 1, 1, "", nil)
+	}
+
+	rotation, err := rotationService.Pause(ctx, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.RotationStatusPaused, rotation.Status)
+
+	for i := 0; i < 5; i++ {
+		bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, bannerID, "paused banner should not be selected")
+	}
+
+	rotation, err = rotationService.Resume(ctx, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, repository.RotationStatusActive, rotation.Status)
+	assert.Equal(t, "Banner 2", rotation.Description)
+
+	views := map[int]int{}
+	countersList, _ := countersRepository.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	for _, counters := range countersList {
+		views[counters.BannerID] += counters.Views
+	}
+
+	assert.Equal(t, map[int]int{1: 7, 2: 2}, views, "resumed banner should keep its views")
+
+	bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, bannerID, "resumed banner should be selected again")
+
+	_, err = rotationService.Archive(ctx, 2)
+	assert.Nil(t, err)
+
+	_, err = rotationService.Resume(ctx, 2)
+	assert.Equal(t, ErrRotationArchived, err)
+
+	_, err = rotationService.Pause(ctx, 3)
+	assert.Equal(t, ErrRotationNotFound, err)
+
+	_, err = rotationService.SetStatus(ctx, 1, "unknown")
+	assert.Equal(t, repository.ErrUnknownStatus, err)
+}
+
+func TestRotationService_SelectBannerByTargeting(t *testing.T) {
+	rotationService := RotationService{}
+
+	rotations := []*repository.Rotation{
+		{BannerID: 1, SlotID: 1},
+		{BannerID: 2, SlotID: 1, Targeting: &repository.Targeting{Groups: []int{1, 2}, DeniedGroups: []int{2}}},
+		{BannerID: 3, SlotID: 1, Targeting: &repository.Targeting{Countries: []string{"KZ", "RU"}}},
+		{BannerID: 4, SlotID: 1, Targeting: &repository.Targeting{DeniedDevices: []string{"mobile"}}},
+		{BannerID: 5, SlotID: 1, Targeting: &repository.Targeting{Languages: []string{"en"}}},
+	}
+
+	testCases := []struct {
+		groupID    int
+		attributes repository.Attributes
+		expected   []int
+	}{
+		{1, nil, []int{1, 2, 4}},
+		{2, nil, []int{1, 4}},
+		{3, repository.Attributes{"country": "kz", "device": "Mobile"}, []int{1, 3}},
+		{3, repository.Attributes{"country": "US", "device": "desktop", "language": "en-US"}, []int{1, 4, 5}},
+		{3, repository.Attributes{"language": "eng"}, []int{1, 4}},
+	}
+
+	for _, testCase := range testCases {
+		bannerIDs := make([]int, 0)
+		for _, rotation := range rotationService.targeted(rotations, testCase.groupID, testCase.attributes) {
+			bannerIDs = append(bannerIDs, rotation.BannerID)
+		}
+
+		assert.Equal(t, testCase.expected, bannerIDs, "group %d, attributes %v", testCase.groupID, testCase.attributes)
+	}
+}
+
+func TestRotationService_SetTargeting(t *testing.T) {
+	rotationService := newTestRotationService(t)
+
+	ctx := context.Background()
+	_, err := rotationService.Add(ctx, repository.Rotation{
+		BannerID:  1,
+		SlotID:    1,
+		Targeting: &repository.Targeting{Countries: []string{""}},
+	})
+	assert.Equal(t, repository.ErrInvalidTargeting, err)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, Description: "Banner 2"})
+
+	rotation, err := rotationService.SetTargeting(ctx, 1, &repository.Targeting{Countries: []string{"KZ"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"KZ"}, rotation.Targeting.Countries)
+	assert.Equal(t, "Banner 1", rotation.Description)
+
+	for i := 0; i < 5; i++ {
+		bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", repository.Attributes{"country": "US"})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, bannerID, "banner should not be selected out of its countries")
+	}
+
+	bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", repository.Attributes{"country": "KZ"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, bannerID, "banner should be selected in its countries")
+
+	rotation, err = rotationService.SetTargeting(ctx, 1, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, rotation.Targeting)
+
+	_, err = rotationService.SetTargeting(ctx, 1, &repository.Targeting{DeniedLanguages: []string{" "}})
+	assert.Equal(t, repository.ErrInvalidTargeting, err)
+
+	_, err = rotationService.SetTargeting(ctx, 3, nil)
+	assert.Equal(t, ErrRotationNotFound, err)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/pkg/errors"
+	"sort"
+	"time"
+)
+
+// Default window the shares of the views of the banners are tracked over
+const DefaultShareWindow = 24 * time.Hour
+
+// Tolerance of the sum of the minimum shares to the rounding
+const shareTolerance = 1e-9
+
+var ErrMinSharesExceeded = errors.New("minimum shares of the banners of the slot can't sum to more than one")
+
+// Sets the weight of the reward of the banner and the bounds of its share of the views of the slot,
+// zero maximum share does not bound the share
+func (b *RotationService) SetShare(
+	ctx context.Context,
+	bannerID int,
+	weight float64,
+	minShare float64,
+	maxShare float64,
+) (*repository.Rotation, error) {
+	rotation, err := b.RotationRepository.FindOneByBannerID(ctx, bannerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for rotation by banner id")
+	}
+
+	if rotation == nil || rotation.ID == 0 {
+		return nil, ErrRotationNotFound
+	}
+
+	rotation.Weight = weight
+	rotation.MinShare = minShare
+	rotation.MaxShare = maxShare
+
+	err = rotation.ValidateShare()
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.validateMinShares(ctx, *rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation, err = b.RotationRepository.Update(ctx, *rotation)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when saving the rotation share")
+	}
+
+	return rotation, nil
+}
+
+// Checks that the minimum shares of the banners of the slot with the rotation do not sum to more than one,
+// the archived banners are not counted
+func (b *RotationService) validateMinShares(ctx context.Context, rotation repository.Rotation) error {
+	if rotation.MinShare <= 0 {
+		return nil
+	}
+
+	rotations, err := b.RotationRepository.FindAllBySlotID(ctx, rotation.SlotID)
+	if err != nil {
+		return errors.Wrap(err, "error when searching for rotations by slot id")
+	}
+
+	total := rotation.MinShare
+	for _, r := range rotations {
+		if r.BannerID == rotation.BannerID || r.Status == repository.RotationStatusArchived {
+			continue
+		}
+
+		total += r.MinShare
+	}
+
+	if total > 1+shareTolerance {
+		return ErrMinSharesExceeded
+	}
+
+	return nil
+}
+
+// Views of the banners of the slot over the share window in all the groups
+type viewShares struct {
+	views map[int]int
+	total int
+}
+
+// Returns the share of the views the banner got
+func (s viewShares) of(bannerID int) float64 {
+	if s.total <= 0 {
+		return 0
+	}
+
+	return float64(s.views[bannerID]) / float64(s.total)
+}
+
+// Returns the share of the views the banner would get if the next view went to another banner
+func (s viewShares) without(bannerID int) float64 {
+	return float64(s.views[bannerID]) / float64(s.total+1)
+}
+
+// Returns the views of the slot every banner got over the share window in all the groups
+func (b *RotationService) shares(ctx context.Context, slotID int, now time.Time) (viewShares, error) {
+	window := b.ShareWindow
+	if window <= 0 {
+		window = DefaultShareWindow
+	}
+
+	countersList, err := b.CountersRepository.FindAllBySlotIDSince(ctx, slotID, now.Add(-window))
+	if err != nil {
+		return viewShares{}, err
+	}
+
+	shares := viewShares{views: make(map[int]int)}
+
+	for _, counters := range countersList {
+		shares.views[counters.BannerID] += counters.Views
+		shares.total += counters.Views
+	}
+
+	return shares, nil
+}
+
+// Returns the definition of the banner constrained by the shares of the banners:
+// the banners that reached their maximum share are left out unless all of them did.
+// The banner whose share would fall below its minimum share without the view is selected without the strategy,
+// the banner furthest below its minimum share goes first
+func (b *RotationService) constrain(
+	define func(rotations []*repository.Rotation) (*repository.Rotation, error),
+	shares viewShares,
+) func(rotations []*repository.Rotation) (*repository.Rotation, error) {
+	return func(rotations []*repository.Rotation) (*repository.Rotation, error) {
+		candidates := make([]*repository.Rotation, 0, len(rotations))
+
+		for _, rotation := range rotations {
+			if rotation.MaxShare > 0 && shares.of(rotation.BannerID) >= rotation.MaxShare {
+				continue
+			}
+
+			candidates = append(candidates, rotation)
+		}
+
+		if len(candidates) == 0 {
+			candidates = rotations
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].BannerID < candidates[j].BannerID
+		})
+
+		var forced *repository.Rotation
+		deficit := 0.0
+
+		for _, rotation := range candidates {
+			if d := rotation.MinShare - shares.without(rotation.BannerID); d > deficit {
+				forced, deficit = rotation, d
+			}
+		}
+
+		if forced != nil {
+			return forced, nil
+		}
+
+		return define(candidates)
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/config"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/koind/banner-rotation/api/internal/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRotationService_SelectBannerByWeight(t *testing.T) {
+	rotationService := RotationService{
+		Strategies: algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies()),
+	}
+
+	now := time.Now().UTC()
+	period := now.Truncate(repository.CountersPeriod)
+	rotations := []*repository.Rotation{
+		{BannerID: 1, SlotID: 1},
+		{BannerID: 2, SlotID: 1},
+	}
+	countersList := []*repository.Counters{
+		{SlotID: 1, GroupID: 1, BannerID: 1, Period: period, Views: 1000, Clicks: 50},
+		{SlotID: 1, GroupID: 1, BannerID: 2, Period: period, Views: 1000, Clicks: 80},
+	}
+	slot := repository.Slot{
+		ID:         1,
+		Strategy:   algorithm.StrategyEpsilonGreedy,
+		Parameters: repository.Parameters{algorithm.ParameterEpsilon: 0},
+		Reward:     repository.RewardClicks,
+	}
+
+	rotation, _, err := rotationService.defineBanner(rotations, countersList, nil, slot, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rotation.BannerID)
+
+	rotations[0].Weight = 2
+
+	rotation, _, err = rotationService.defineBanner(rotations, countersList, nil, slot, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, rotation.BannerID, "clicks on the heavier banner should be worth more")
+}
+
+func TestRotationService_SelectBannerByShare(t *testing.T) {
+	rotationService := newTestRotationService(t)
+
+	ctx := context.Background()
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1})
+	rotationService.Add(ctx, repository.Rotation{BannerID: 3, SlotID: 1, MinShare: 0.5})
+
+	views := map[int]int{}
+	for i := 0; i < 40; i++ {
+		bannerID, _, err := rotationService.SelectBanner(ctx, 1, i%2+1, "", nil)
+		assert.Nil(t, err)
+		views[bannerID]++
+	}
+
+	assert.True(t, views[3] >= 20, "banner should get its minimum share in all the groups")
+	assert.True(t, views[1] > 0 && views[2] > 0, "strategy should keep selecting the other banners")
+
+	_, err := rotationService.SetShare(ctx, 3, 1, 0, 0.1)
+	assert.Nil(t, err)
+
+	for i := 0; i < 10; i++ {
+		bannerID, _, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+		assert.Nil(t, err)
+		assert.NotEqual(t, 3, bannerID, "banner over its maximum share should not be selected")
+	}
+}
+
+func TestRotationService_SetShare(t *testing.T) {
+	rotationService := RotationService{
+		RotationRepository: memory.NewRotationRepository(),
+	}
+
+	ctx := context.Background()
+	_, err := rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, MinShare: 0.6, MaxShare: 0.5})
+	assert.Equal(t, repository.ErrInvalidShare, err)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1, Description: "Banner 1"})
+
+	rotation, err := rotationService.SetShare(ctx, 1, 2, 0.1, 0.5)
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, rotation.Weight)
+	assert.Equal(t, 0.1, rotation.MinShare)
+	assert.Equal(t, 0.5, rotation.MaxShare)
+	assert.Equal(t, "Banner 1", rotation.Description)
+
+	_, err = rotationService.SetShare(ctx, 1, -1, 0, 0)
+	assert.Equal(t, repository.ErrInvalidWeight, err)
+
+	_, err = rotationService.SetShare(ctx, 1, 1, 0, 1.5)
+	assert.Equal(t, repository.ErrInvalidShare, err)
+
+	_, err = rotationService.SetShare(ctx, 2, 1, 0, 0)
+	assert.Equal(t, ErrRotationNotFound, err)
+
+	rotationService.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1, MinShare: 0.5})
+
+	_, err = rotationService.Add(ctx, repository.Rotation{BannerID: 3, SlotID: 1, MinShare: 0.5})
+	assert.Equal(t, ErrMinSharesExceeded, err, "minimum shares of the slot should not sum to more than one")
+
+	_, err = rotationService.Add(ctx, repository.Rotation{BannerID: 3, SlotID: 2, MinShare: 0.5})
+	assert.Nil(t, err, "minimum shares of the other slots should not be counted")
+
+	_, err = rotationService.SetShare(ctx, 1, 1, 0.6, 0)
+	assert.Equal(t, ErrMinSharesExceeded, err)
+
+	_, err = rotationService.SetShare(ctx, 2, 1, 0.9, 0)
+	assert.Nil(t, err, "minimum share of the banner itself should be replaced")
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrSlotNotFound = errors.New("slot not found")
+	ErrSlotExists   = errors.New("slot already exists")
+	ErrSlotDisabled = errors.New("slot is disabled")
+)
+
+// Adds a new slot with its settings
+func (b *RotationService) AddSlot(ctx context.Context, slot repository.Slot) (*repository.Slot, error) {
+	err := b.validateSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := b.SlotRepository.FindOneByID(ctx, slot.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for slot by id")
+	}
+
+	if stored != nil {
+		return nil, ErrSlotExists
+	}
+
+	newSlot, err := b.SlotRepository.Save(ctx, slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when adding the slot")
+	}
+
+	return newSlot, nil
+}
+
+// Replaces the settings of the slot
+func (b *RotationService) UpdateSlot(ctx context.Context, slot repository.Slot) (*repository.Slot, error) {
+	err := b.validateSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := b.SlotRepository.FindOneByID(ctx, slot.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for slot by id")
+	}
+
+	if stored == nil {
+		return nil, ErrSlotNotFound
+	}
+
+	updated, err := b.SlotRepository.Save(ctx, slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when updating the slot")
+	}
+
+	return updated, nil
+}
+
+// Returns the slot with its settings
+func (b *RotationService) FindSlot(ctx context.Context, slotID int) (*repository.Slot, error) {
+	slot, err := b.SlotRepository.FindOneByID(ctx, slotID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for slot by id")
+	}
+
+	if slot == nil {
+		return nil, ErrSlotNotFound
+	}
+
+	return slot, nil
+}
+
+// Returns all the slots with settings ordered by id
+func (b *RotationService) FindAllSlots(ctx context.Context) ([]*repository.Slot, error) {
+	slots, err := b.SlotRepository.FindAll(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for slots")
+	}
+
+	return slots, nil
+}
+
+// Removes the settings of the slot, its banners stay in the rotation and are selected with the default settings
+func (b *RotationService) RemoveSlot(ctx context.Context, slotID int) error {
+	_, err := b.FindSlot(ctx, slotID)
+	if err != nil {
+		return err
+	}
+
+	err = b.SlotRepository.Remove(ctx, slotID)
+	if err != nil {
+		return errors.Wrap(err, "error while removing the slot")
+	}
+
+	return nil
+}
+
+// Checks the slot, its strategy with the parameters and its reward, empty ones take the defaults
+func (b *RotationService) validateSlot(slot repository.Slot) error {
+	err := slot.Validate()
+	if err != nil {
+		return err
+	}
+
+	if slot.Strategy != "" {
+		if !b.Strategies.Has(slot.Strategy) {
+			return algorithm.ErrUnknownStrategy
+		}
+
+		err = b.Strategies.Validate(slot.Strategy, algorithm.Parameters(slot.Parameters))
+		if err != nil {
+			return errors.Wrap(err, "invalid strategy parameters")
+		}
+	}
+
+	switch slot.Reward {
+	case "", repository.RewardClicks, repository.RewardConversions:
+	default:
+		return ErrUnknownReward
+	}
+
+	return nil
+}
+
+// Shows the fallback banner of the slot without the strategy, a view is recorded for it
+func (b *RotationService) fallback(
+	ctx context.Context,
+	slot repository.Slot,
+	groupID int,
+	attributes repository.Attributes,
+) ([]int, []*repository.Statistics, error) {
+	statistics, err := b.StatisticsService.Save(ctx, repository.Statistics{
+		Type:       repository.StatisticsTypeView,
+		BannerID:   slot.FallbackBannerID,
+		SlotID:     slot.ID,
+		GroupID:    groupID,
+		Position:   1,
+		Attributes: attributes,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error while save view")
+	}
+
+	return []int{slot.FallbackBannerID}, []*repository.Statistics{statistics}, nil
+}
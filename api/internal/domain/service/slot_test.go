@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/config"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/koind/banner-rotation/api/internal/storage/memory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRotationService_Slots(t *testing.T) {
+	rotationService := RotationService{
+		SlotRepository: memory.NewSlotRepository(),
+		Strategies:     algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies()),
+	}
+
+	ctx := context.Background()
+	slot := repository.Slot{
+		ID:          2,
+		Name:        "Sidebar",
+		Description: "Right sidebar of the article",
+		Width:       300,
+		Height:      250,
+		Strategy:    algorithm.StrategyEpsilonGreedy,
+		Parameters:  repository.Parameters{algorithm.ParameterEpsilon: 0.2},
+		Reward:      repository.RewardClicks,
+	}
+
+	newSlot, err := rotationService.AddSlot(ctx, slot)
+	assert.Nil(t, err)
+	assert.Equal(t, &slot, newSlot)
+	assert.True(t, newSlot.IsEnabled(), "slot without the flag should be enabled")
+
+	_, err = rotationService.AddSlot(ctx, slot)
+	assert.Equal(t, ErrSlotExists, err)
+
+	rotationService.AddSlot(ctx, repository.Slot{ID: 1, Name: "Header"})
+
+	slots, err := rotationService.FindAllSlots(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(slots))
+	assert.Equal(t, 1, slots[0].ID, "slots should be ordered by id")
+
+	enabled := false
+	slot.Name = "Footer"
+	slot.Enabled = &enabled
+
+	updated, err := rotationService.UpdateSlot(ctx, slot)
+	assert.Nil(t, err)
+	assert.Equal(t, "Footer", updated.Name)
+	assert.False(t, updated.IsEnabled())
+
+	found, err := rotationService.FindSlot(ctx, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, updated, found)
+
+	slot.Description = "Bottom of the page"
+	slot.Enabled = nil
+
+	updated, err = rotationService.UpdateSlot(ctx, slot)
+	assert.Nil(t, err)
+	assert.False(t, updated.IsEnabled(), "slot without the flag should keep the stored one")
+
+	_, err = rotationService.UpdateSlot(ctx, repository.Slot{ID: 3})
+	assert.Equal(t, ErrSlotNotFound, err)
+
+	_, err = rotationService.AddSlot(ctx, repository.Slot{ID: 0})
+	assert.Equal(t, repository.ErrInvalidSlot, err)
+
+	_, err = rotationService.AddSlot(ctx, repository.Slot{ID: 3, Width: -1})
+	assert.Equal(t, repository.ErrInvalidSlot, err)
+
+	_, err = rotationService.AddSlot(ctx, repository.Slot{ID: 3, Strategy: "unknown"})
+	assert.Equal(t, algorithm.ErrUnknownStrategy, err)
+
+	_, err = rotationService.AddSlot(ctx, repository.Slot{ID: 3, Reward: "unknown"})
+	assert.Equal(t, ErrUnknownReward, err)
+
+	assert.Nil(t, rotationService.RemoveSlot(ctx, 2))
+
+	_, err = rotationService.FindSlot(ctx, 2)
+	assert.Equal(t, ErrSlotNotFound, err)
+
+	assert.Equal(t, ErrSlotNotFound, rotationService.RemoveSlot(ctx, 2))
+}
+
+func TestRotationService_SelectBannerBySlot(t *testing.T) {
+	rotationService := newTestRotationService(t)
+
+	ctx := context.Background()
+	rotationService.Add(ctx, repository.Rotation{
+		BannerID:  1,
+		SlotID:    1,
+		Targeting: &repository.Targeting{Countries: []string{"KZ"}},
+	})
+
+	_, _, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+	assert.Equal(t, ErrRotationsListEmpty, errors.Cause(err))
+
+	rotationService.AddSlot(ctx, repository.Slot{ID: 1, FallbackBannerID: 9})
+
+	bannerID, statistics, err := rotationService.SelectBanner(ctx, 1, 1, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 9, bannerID, "fallback banner should be shown when no banner can be selected")
+	assert.Equal(t, repository.StatisticsTypeView, statistics.Type)
+
+	bannerID, _, err = rotationService.SelectBanner(ctx, 1, 1, "", repository.Attributes{"country": "KZ"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, bannerID)
+
+	enabled := false
+	rotationService.UpdateSlot(ctx, repository.Slot{ID: 1, FallbackBannerID: 9, Enabled: &enabled})
+
+	_, _, err = rotationService.SelectBanner(ctx, 1, 1, "", repository.Attributes{"country": "KZ"})
+	assert.Equal(t, ErrSlotDisabled, err)
+}
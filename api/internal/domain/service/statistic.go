@@ -9,34 +9,55 @@ import (
 
 // The service interface statistics
 type StatisticsServiceInterface interface {
-	// Saves the statistics
-	Save(ctx context.Context, rotation repository.Rotation, groupID int, statisticType int) (*repository.Statistics, error)
+	// Saves the statistics of the banner, the time of the statistics is set when it is saved
+	Save(ctx context.Context, statistics repository.Statistics) (*repository.Statistics, error)
 }
 
-// Statistics service
+// Statistics service, the statistics and the counters are saved in one transaction of the transactor,
+// without the transactor they are saved one by one
 type StatisticsService struct {
 	StatisticsRepository repository.StatisticsRepositoryInterface
+	CountersRepository   repository.CountersRepositoryInterface
+	Transactor           repository.TransactorInterface
 }
 
-// Saves the statistics
+// Saves the statistics of the banner and adds it to the counters atomically,
+// the time of the statistics is set when it is saved
 func (s *StatisticsService) Save(
 	ctx context.Context,
-	rotation repository.Rotation,
-	groupID int,
-	statisticType int,
+	statistics repository.Statistics,
 ) (*repository.Statistics, error) {
-	statistics := repository.Statistics{
-		Type:      statisticType,
-		BannerID:  rotation.BannerID,
-		SlotID:    rotation.SlotID,
-		GroupID:   groupID,
-		CreatedAt: time.Now().UTC(),
-	}
+	statistics.CreatedAt = time.Now().UTC()
+
+	var newStatistics *repository.Statistics
+
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		newStatistics, err = s.StatisticsRepository.Add(ctx, statistics)
+		if err != nil {
+			return errors.Wrap(err, "error saving statistics")
+		}
+
+		err = s.CountersRepository.Add(ctx, repository.NewCounters(*newStatistics))
+		if err != nil {
+			return errors.Wrap(err, "error updating counters")
+		}
 
-	newStatistics, err := s.StatisticsRepository.Add(ctx, statistics)
+		return nil
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "error saving statistics")
+		return nil, err
 	}
 
 	return newStatistics, nil
 }
+
+// Runs the function in one transaction of the transactor, without the transactor runs it as is
+func (s *StatisticsService) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.Transactor == nil {
+		return fn(ctx)
+	}
+
+	return s.Transactor.WithinTransaction(ctx, fn)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/koind/banner-rotation/api/internal/domain/repository"
 	"github.com/koind/banner-rotation/api/internal/storage/memory"
 	"github.com/stretchr/testify/assert"
@@ -12,15 +13,18 @@ import (
 func TestStatisticsService_Save(t *testing.T) {
 	statisticsService := StatisticsService{
 		StatisticsRepository: memory.NewStatisticsRepository(),
+		CountersRepository:   memory.NewCountersRepository(),
 	}
 
-	testCases := map[string]struct {
+	testCases := []struct {
+		name               string
 		rotation           repository.Rotation
 		groupID            int
 		statisticsType     int
 		expectedStatistics repository.Statistics
 	}{
-		"Save statistics with type type view": {
+		{
+			name: "Save statistics with type type view",
 			rotation: repository.Rotation{
 				ID:          1,
 				BannerID:    13,
@@ -38,7 +42,8 @@ func TestStatisticsService_Save(t *testing.T) {
 				GroupID:  2,
 			},
 		},
-		"Save statistics with type type click": {
+		{
+			name: "Save statistics with type type click",
 			rotation: repository.Rotation{
 				ID:          1,
 				BannerID:    13,
@@ -59,15 +64,105 @@ func TestStatisticsService_Save(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		statistics, _ := statisticsService.Save(
-			context.Background(),
-			testCase.rotation,
-			testCase.groupID,
-			testCase.statisticsType,
-		)
+		statistics, _ := statisticsService.Save(context.Background(), repository.Statistics{
+			Type:     testCase.statisticsType,
+			BannerID: testCase.rotation.BannerID,
+			SlotID:   testCase.rotation.SlotID,
+			GroupID:  testCase.groupID,
+		})
 
 		testCase.expectedStatistics.CreatedAt = statistics.CreatedAt
 
-		assert.Equal(t, &testCase.expectedStatistics, statistics, "values must match")
+		assert.Equal(t, &testCase.expectedStatistics, statistics, testCase.name)
 	}
 }
+
+func TestStatisticsService_SaveUpdatesCounters(t *testing.T) {
+	countersRepository := memory.NewCountersRepository()
+	statisticsService := StatisticsService{
+		StatisticsRepository: memory.NewStatisticsRepository(),
+		CountersRepository:   countersRepository,
+	}
+
+	ctx := context.Background()
+	view := repository.Statistics{Type: repository.StatisticsTypeView, BannerID: 13, SlotID: 5, GroupID: 2}
+
+	statisticsService.Save(ctx, view)
+	statisticsService.Save(ctx, view)
+	statisticsService.Save(ctx, repository.Statistics{Type: repository.StatisticsTypeClick, BannerID: 13, SlotID: 5, GroupID: 2})
+	statistics, _ := statisticsService.Save(ctx, repository.Statistics{
+		Type:     repository.StatisticsTypeConversion,
+		BannerID: 13,
+		SlotID:   5,
+		GroupID:  2,
+		Value:    10.5,
+	})
+	statisticsService.Save(ctx, repository.Statistics{Type: repository.StatisticsTypeView, BannerID: 13, SlotID: 5, GroupID: 3})
+
+	countersList, err := countersRepository.FindAllBySlotIDAndGroupID(ctx, 5, 2)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]*repository.Counters{
+			{
+				SlotID:      5,
+				GroupID:     2,
+				BannerID:    13,
+				Period:      statistics.CreatedAt.Truncate(repository.CountersPeriod),
+				Views:       2,
+				Clicks:      1,
+				Conversions: 1,
+				Value:       10.5,
+
+				InverseProbability: 2,
+			},
+		},
+		countersList,
+	)
+}
+
+// Transactor that records the results of the transactions
+type recordingTransactor struct {
+	results []error
+}
+
+func (r *recordingTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	r.results = append(r.results, err)
+
+	return err
+}
+
+// Counters repository that fails to add the counters
+type failingCountersRepository struct {
+	*memory.CountersRepository
+}
+
+func (f failingCountersRepository) Add(ctx context.Context, counters ...repository.Counters) error {
+	return errors.New("counters are unavailable")
+}
+
+func TestStatisticsService_SaveWithinTransaction(t *testing.T) {
+	transactor := &recordingTransactor{}
+	statisticsService := StatisticsService{
+		StatisticsRepository: memory.NewStatisticsRepository(),
+		CountersRepository:   memory.NewCountersRepository(),
+		Transactor:           transactor,
+	}
+
+	ctx := context.Background()
+	view := repository.Statistics{Type: repository.StatisticsTypeView, BannerID: 13, SlotID: 5, GroupID: 2}
+
+	statistics, err := statisticsService.Save(ctx, view)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, statistics.ID)
+	assert.Equal(t, []error{nil}, transactor.results)
+
+	statisticsService.CountersRepository = failingCountersRepository{memory.NewCountersRepository()}
+
+	statistics, err = statisticsService.Save(ctx, view)
+	assert.NotNil(t, err)
+	assert.Nil(t, statistics)
+	assert.Len(t, transactor.results, 2)
+	assert.NotNil(t, transactor.results[1], "the transaction should be rolled back when the counters fail")
+}
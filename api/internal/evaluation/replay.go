@@ -0,0 +1,411 @@
+package evaluation
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+var (
+	ErrEventsEmpty = errors.New("events list can't be empty")
+)
+
+// Logged impression of the banner with the reward it got
+type Event struct {
+	BannerID   int
+	Reward     float64
+	Attributes repository.Attributes
+	CreatedAt  time.Time
+}
+
+// Results of the banner in the replay
+type BannerReport struct {
+	BannerID int
+
+	// Logged impressions and reward of the banner
+	LoggedViews  int
+	LoggedReward float64
+
+	// Impressions and reward of the banner the strategy agreed with
+	Views  int
+	Reward float64
+
+	// Share of the accepted impressions the strategy gave to the banner
+	TrafficShare float64
+}
+
+// Logged CTR of the banner
+func (b BannerReport) LoggedCTR() float64 {
+	if b.LoggedViews == 0 {
+		return 0
+	}
+
+	return b.LoggedReward / float64(b.LoggedViews)
+}
+
+// Estimated CTR of the banner under the strategy
+func (b BannerReport) CTR() float64 {
+	if b.Views == 0 {
+		return 0
+	}
+
+	return b.Reward / float64(b.Views)
+}
+
+// Results of the replay of the strategy
+type Report struct {
+	// Number of logged impressions
+	Events int
+
+	// Number of impressions where the strategy selected the logged banner
+	Accepted int
+
+	// Reward of the accepted impressions
+	Reward float64
+
+	// Estimated CTR of the strategy
+	CTR float64
+
+	// Expected reward of always showing the banner with the best logged CTR
+	// minus the reward of the strategy over the accepted impressions
+	Regret float64
+
+	Banners []BannerReport
+}
+
+// Events returns the logged impressions of the statistics in the order of their creation,
+// every click or conversion is attributed to the latest impression of the banner in the slot and group
+// that has no reward yet. The reward is the click or the conversion value depending on the reward of the slot,
+// the conversion values are divided by the max conversion value when it is set
+func Events(statisticsList []*repository.Statistics, reward string, maxConversionValue float64) []Event {
+	sorted := make([]*repository.Statistics, len(statisticsList))
+	copy(sorted, statisticsList)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].ID < sorted[j].ID
+		}
+
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	type key struct {
+		bannerID int
+		slotID   int
+		groupID  int
+	}
+
+	events := make([]Event, 0)
+	pending := make(map[key][]int)
+
+	for _, statistics := range sorted {
+		k := key{statistics.BannerID, statistics.SlotID, statistics.GroupID}
+
+		if statistics.IsTypeView() {
+			pending[k] = append(pending[k], len(events))
+			events = append(events, Event{
+				BannerID:   statistics.BannerID,
+				Attributes: statistics.Attributes,
+				CreatedAt:  statistics.CreatedAt,
+			})
+
+			continue
+		}
+
+		value := 0.0
+		if statistics.IsTypeClick() && reward != repository.RewardConversions {
+			value = 1
+		}
+		if statistics.IsTypeConversion() && reward == repository.RewardConversions {
+			value = statistics.Value
+			if maxConversionValue > 0 {
+				value = math.Min(value/maxConversionValue, 1)
+			}
+		}
+
+		views := pending[k]
+		if value == 0 || len(views) == 0 {
+			continue
+		}
+
+		events[views[len(views)-1]].Reward = value
+		pending[k] = views[:len(views)-1]
+	}
+
+	return events
+}
+
+// ReadEvents reads the exported event log, one statistics encoded in json per line,
+// as it is published to the queue
+func ReadEvents(r io.Reader) ([]*repository.Statistics, error) {
+	statisticsList := make([]*repository.Statistics, 0)
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		statistics := new(repository.Statistics)
+		if err := json.Unmarshal(scanner.Bytes(), statistics); err != nil {
+			return nil, err
+		}
+
+		statisticsList = append(statisticsList, statistics)
+	}
+
+	return statisticsList, scanner.Err()
+}
+
+// Factory creates the algorithm from the discounted counts and total rewards of the arms
+type Factory func(counts []float64, rewards []float64) (algorithm.Algorithm, error)
+
+// Policy of the replay that selects the arm for every logged impression
+type policy interface {
+	reset(nArms int) error
+	selectArm(event Event) (int, error)
+	update(arm int, event Event) error
+}
+
+// Replay evaluates the algorithm on the logged impressions with the replay method:
+// the impressions where the algorithm selects the logged banner are accepted and update the algorithm,
+// the others are skipped. The logged banners must be chosen uniformly at random for the estimate to be unbiased.
+// Contextual algorithms get the features of the attributes of every impression
+func Replay(a algorithm.Algorithm, events []Event) (*Report, error) {
+	return replay(&onlinePolicy{algorithm: a}, events)
+}
+
+// ReplayDiscounted evaluates the strategy that discounts old observations the way the service runs it:
+// the accepted impressions are added to the counters of their period, and for every impression
+// the algorithm is created from the counters weighed by the age of their period at the time of the impression.
+// Nil discount keeps all the observations. The weighted strategy gets the rewards divided by the probabilities
+// the algorithm selected the arms with, averaged over the counters as the service does
+func ReplayDiscounted(factory Factory, discount algorithm.Discount, weighted bool, events []Event) (*Report, error) {
+	return replay(&discountedPolicy{factory: factory, discount: discount, weighted: weighted}, events)
+}
+
+// Replays the impressions through the policy
+func replay(p policy, events []Event) (*Report, error) {
+	if len(events) == 0 {
+		return nil, ErrEventsEmpty
+	}
+
+	bannerIDs := make([]int, 0)
+	arms := make(map[int]int)
+
+	for _, event := range events {
+		if _, has := arms[event.BannerID]; !has {
+			arms[event.BannerID] = 0
+			bannerIDs = append(bannerIDs, event.BannerID)
+		}
+	}
+
+	sort.Ints(bannerIDs)
+
+	banners := make([]BannerReport, len(bannerIDs))
+	for i, bannerID := range bannerIDs {
+		arms[bannerID] = i
+		banners[i].BannerID = bannerID
+	}
+
+	err := p.reset(len(bannerIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Events: len(events)}
+
+	for _, event := range events {
+		logged := arms[event.BannerID]
+		banners[logged].LoggedViews++
+		banners[logged].LoggedReward += event.Reward
+
+		arm, err := p.selectArm(event)
+		if err != nil {
+			return nil, err
+		}
+
+		if arm != logged {
+			continue
+		}
+
+		err = p.update(logged, event)
+		if err != nil {
+			return nil, err
+		}
+
+		banners[logged].Views++
+		banners[logged].Reward += event.Reward
+		report.Accepted++
+		report.Reward += event.Reward
+	}
+
+	bestCTR := 0.0
+	for i := range banners {
+		bestCTR = math.Max(bestCTR, banners[i].LoggedCTR())
+
+		if report.Accepted > 0 {
+			banners[i].TrafficShare = float64(banners[i].Views) / float64(report.Accepted)
+		}
+	}
+
+	if report.Accepted > 0 {
+		report.CTR = report.Reward / float64(report.Accepted)
+	}
+
+	report.Regret = bestCTR*float64(report.Accepted) - report.Reward
+	report.Banners = banners
+
+	return report, nil
+}
+
+// Policy that updates the algorithm with every accepted impression
+type onlinePolicy struct {
+	algorithm algorithm.Algorithm
+}
+
+// Resets the algorithm for the number of arms
+func (o *onlinePolicy) reset(nArms int) error {
+	return o.algorithm.Reset(nArms)
+}
+
+// Selects the arm, the contextual algorithm gets the features of the attributes of the impression
+func (o *onlinePolicy) selectArm(event Event) (int, error) {
+	if contextual, isContextual := o.algorithm.(algorithm.Contextual); isContextual {
+		err := contextual.SetContext(algorithm.HashFeatures(event.Attributes, contextual.Dimension()))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return o.algorithm.SelectArm(), nil
+}
+
+// Updates the algorithm with the reward of the impression
+func (o *onlinePolicy) update(arm int, event Event) error {
+	return o.algorithm.Update(arm, event.Reward)
+}
+
+// Counters of the arms in the period
+type periodCounters struct {
+	period  time.Time
+	views   []float64
+	rewards []float64
+
+	// Sums of the inverse probabilities of the views
+	inverse []float64
+}
+
+// Policy that creates the algorithm from the discounted counters for every impression
+type discountedPolicy struct {
+	factory  Factory
+	discount algorithm.Discount
+	weighted bool
+	nArms    int
+	periods  []*periodCounters
+
+	// Probability the last arm was selected with, zero when the algorithm does not know it
+	probability float64
+}
+
+// Forgets the counters
+func (d *discountedPolicy) reset(nArms int) error {
+	d.nArms = nArms
+	d.periods = nil
+
+	return nil
+}
+
+// Selects the arm of the algorithm created from the counters weighed by their age at the time of the impression,
+// the rewards of the weighted strategy are estimated from the counters of every period when they are discounted
+// and from the totals of the arms otherwise, as the service keeps the totals for the strategies without discount
+func (d *discountedPolicy) selectArm(event Event) (int, error) {
+	counts := make([]float64, d.nArms)
+	rewards := make([]float64, d.nArms)
+	inverse := make([]float64, d.nArms)
+
+	for _, counters := range d.periods {
+		weight := 1.0
+		if d.discount != nil {
+			weight = d.discount(event.CreatedAt.Sub(counters.period))
+		}
+
+		if weight == 0 {
+			continue
+		}
+
+		for i := range counts {
+			reward := counters.rewards[i]
+			if d.weighted && d.discount != nil {
+				reward = estimate(reward, counters.views[i], counters.inverse[i])
+			}
+
+			counts[i] += weight * counters.views[i]
+			rewards[i] += weight * reward
+			inverse[i] += weight * counters.inverse[i]
+		}
+	}
+
+	if d.weighted && d.discount == nil {
+		for i := range rewards {
+			rewards[i] = estimate(rewards[i], counts[i], inverse[i])
+		}
+	}
+
+	a, err := d.factory(counts, rewards)
+	if err != nil {
+		return 0, err
+	}
+
+	arm := a.SelectArm()
+
+	d.probability = 0
+	if p, isProbabilistic := a.(algorithm.Probabilistic); isProbabilistic {
+		d.probability = p.Probabilities()[arm]
+	}
+
+	return arm, nil
+}
+
+// Adds the impression to the counters of its period, the impressions come in the order of their creation
+func (d *discountedPolicy) update(arm int, event Event) error {
+	period := event.CreatedAt.UTC().Truncate(repository.CountersPeriod)
+
+	if len(d.periods) == 0 || !d.periods[len(d.periods)-1].period.Equal(period) {
+		d.periods = append(d.periods, &periodCounters{
+			period:  period,
+			views:   make([]float64, d.nArms),
+			rewards: make([]float64, d.nArms),
+			inverse: make([]float64, d.nArms),
+		})
+	}
+
+	counters := d.periods[len(d.periods)-1]
+	counters.views[arm]++
+	counters.rewards[arm] += event.Reward
+
+	// the view of unknown probability counts as one as in the counters of the service
+	inverse := 1.0
+	if d.probability > 0 {
+		inverse = 1 / d.probability
+	}
+
+	counters.inverse[arm] += inverse
+
+	return nil
+}
+
+// Returns the reward divided by the average probability of the views
+func estimate(reward float64, views float64, inverse float64) float64 {
+	if views <= 0 || inverse <= 0 {
+		return reward
+	}
+
+	return reward * inverse / views
+}
@@ -0,0 +1,171 @@
+package evaluation
+
+import (
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvents(t *testing.T) {
+	now := time.Now().UTC()
+	statisticsList := []*repository.Statistics{
+		{ID: 4, Type: repository.StatisticsTypeClick, BannerID: 1, SlotID: 1, GroupID: 1, CreatedAt: now.Add(3 * time.Second)},
+		{ID: 1, Type: repository.StatisticsTypeView, BannerID: 1, SlotID: 1, GroupID: 1, CreatedAt: now},
+		{ID: 2, Type: repository.StatisticsTypeView, BannerID: 2, SlotID: 1, GroupID: 1, CreatedAt: now.Add(time.Second)},
+		{ID: 3, Type: repository.StatisticsTypeView, BannerID: 1, SlotID: 1, GroupID: 1, CreatedAt: now.Add(2 * time.Second)},
+		{ID: 5, Type: repository.StatisticsTypeConversion, BannerID: 2, SlotID: 1, GroupID: 1, Value: 50, CreatedAt: now.Add(4 * time.Second)},
+		{ID: 6, Type: repository.StatisticsTypeClick, BannerID: 2, SlotID: 1, GroupID: 2, CreatedAt: now.Add(5 * time.Second)},
+	}
+
+	testCases := []struct {
+		name     string
+		reward   string
+		expected []Event
+	}{
+		{
+			name:   "click is attributed to the latest view",
+			reward: repository.RewardClicks,
+			expected: []Event{
+				{BannerID: 1, CreatedAt: now},
+				{BannerID: 2, CreatedAt: now.Add(time.Second)},
+				{BannerID: 1, Reward: 1, CreatedAt: now.Add(2 * time.Second)},
+			},
+		},
+		{
+			name:   "conversion value is scaled",
+			reward: repository.RewardConversions,
+			expected: []Event{
+				{BannerID: 1, CreatedAt: now},
+				{BannerID: 2, Reward: 0.5, CreatedAt: now.Add(time.Second)},
+				{BannerID: 1, CreatedAt: now.Add(2 * time.Second)},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, Events(statisticsList, testCase.reward, 100), testCase.name)
+	}
+}
+
+func TestReadEvents(t *testing.T) {
+	log := `{"id":1,"type":1,"bannerId":3,"slotId":1,"groupId":2,"createdAt":"2019-11-18T19:05:52Z"}
+
+{"id":2,"type":2,"bannerId":3,"slotId":1,"groupId":2,"createdAt":"2019-11-18T19:05:53Z"}`
+
+	statisticsList, err := ReadEvents(strings.NewReader(log))
+	assert.Nil(t, err)
+	assert.Len(t, statisticsList, 2)
+	assert.Equal(t, 3, statisticsList[1].BannerID)
+	assert.True(t, statisticsList[1].IsTypeClick())
+
+	_, err = ReadEvents(strings.NewReader("not json"))
+	assert.NotNil(t, err)
+}
+
+func TestReplay(t *testing.T) {
+	_, err := Replay(&algorithm.UCB1{}, nil)
+	assert.Equal(t, ErrEventsEmpty, err)
+
+	// uniformly random logging policy over banners with the CTR of 0.1 and 0.5
+	r := rand.New(rand.NewSource(1))
+	events := make([]Event, 0, 20000)
+	for i := 0; i < 20000; i++ {
+		bannerID := 10 + r.Intn(2)
+		ctr := 0.1
+		if bannerID == 11 {
+			ctr = 0.5
+		}
+
+		event := Event{BannerID: bannerID}
+		if r.Float64() < ctr {
+			event.Reward = 1
+		}
+
+		events = append(events, event)
+	}
+
+	uniform, _ := algorithm.NewFixedSplit(nil, nil, algorithm.NewLockedSource(1))
+	report, err := Replay(uniform, events)
+	assert.Nil(t, err)
+	assert.Equal(t, 20000, report.Events)
+	assert.InDelta(t, 10000, report.Accepted, 500, "half of the impressions should be accepted")
+	assert.InDelta(t, 0.3, report.CTR, 0.03, "uniform strategy should get the average CTR")
+	assert.InDelta(t, 0.5, report.Banners[1].TrafficShare, 0.05)
+	assert.InDelta(t, 0.5, report.Banners[1].LoggedCTR(), 0.03)
+	assert.True(t, report.Regret > 1000, "uniform strategy should lose on the worse banner")
+
+	greedy, _ := algorithm.NewEpsilonGreedy(0.1, nil, nil, algorithm.NewLockedSource(1))
+	report, err = Replay(greedy, events)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{10, 11}, []int{report.Banners[0].BannerID, report.Banners[1].BannerID})
+	assert.True(t, report.Banners[1].TrafficShare > 0.8, "greedy strategy should show the best banner")
+	assert.True(t, report.CTR > 0.4, "greedy strategy should get close to the best CTR")
+}
+
+func TestReplayDiscounted(t *testing.T) {
+	var counts [][]float64
+	factory := func(c []float64, rewards []float64) (algorithm.Algorithm, error) {
+		counts = append(counts, c)
+
+		return algorithm.NewDiscountedUCB(c, rewards)
+	}
+
+	_, err := ReplayDiscounted(factory, nil, false, nil)
+	assert.Equal(t, ErrEventsEmpty, err)
+
+	start := time.Date(2019, 11, 18, 10, 0, 0, 0, time.UTC)
+	events := []Event{
+		{BannerID: 10, Reward: 1, CreatedAt: start},
+		{BannerID: 10, CreatedAt: start.Add(30 * time.Minute)},
+		{BannerID: 10, Reward: 1, CreatedAt: start.Add(3 * time.Hour)},
+	}
+
+	report, err := ReplayDiscounted(factory, nil, false, events)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.Accepted)
+	assert.Equal(t, [][]float64{{0}, {1}, {2}}, counts, "all the observations should be kept")
+
+	counts = nil
+	report, err = ReplayDiscounted(factory, algorithm.SlidingWindow(2*time.Hour), false, events)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.Accepted)
+	assert.Equal(t, [][]float64{{0}, {1}, {0}}, counts, "the observations out of the window should be forgotten")
+
+	counts = nil
+	_, err = ReplayDiscounted(factory, algorithm.HalfLife(time.Hour), false, events)
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.25, counts[2][0], 1e-9, "the period of the observations should be three hours old")
+}
+
+func TestReplayDiscountedWeighted(t *testing.T) {
+	var rewards [][]float64
+	source := algorithm.NewLockedSource(1)
+	factory := func(counts []float64, r []float64) (algorithm.Algorithm, error) {
+		rewards = append(rewards, r)
+
+		// the uniform exploration selects every arm with the probability of one half
+		return algorithm.NewEXP3(1, make([]int, len(counts)), r, source)
+	}
+
+	start := time.Date(2019, 11, 18, 10, 0, 0, 0, time.UTC)
+	events := make([]Event, 0, 101)
+	for i := 0; i < 100; i++ {
+		events = append(events, Event{BannerID: 10 + i%2, Reward: float64(1 - i%2), CreatedAt: start})
+	}
+	events = append(events, Event{BannerID: 11, CreatedAt: start})
+
+	report, err := ReplayDiscounted(factory, nil, true, events)
+	assert.Nil(t, err)
+	assert.True(t, report.Banners[0].Reward > 0)
+	assert.InDelta(
+		t,
+		2*report.Banners[0].Reward,
+		rewards[len(rewards)-1][0],
+		1e-9,
+		"the rewards should be divided by the probability of the selected arm",
+	)
+}
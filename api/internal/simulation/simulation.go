@@ -0,0 +1,298 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"github.com/BurntSushi/toml"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/koind/banner-rotation/api/internal/domain/service"
+	"github.com/koind/banner-rotation/api/internal/storage/memory"
+	"math/rand"
+	"strconv"
+)
+
+var (
+	ErrBannersEmpty   = errors.New("banners list can't be empty")
+	ErrInvalidViews   = errors.New("views must be greater than zero")
+	ErrInvalidCTR     = errors.New("ctr must be within [0, 1]")
+	ErrInvalidWeights = errors.New("weights of the groups must not be negative and can't all be zero")
+)
+
+// The slot the banners are rotated in
+const slotID = 1
+
+// Synthetic banner with its true CTR
+type Banner struct {
+	ID  int
+	CTR float64
+
+	// CTR of the banner in the groups, the keys are the group ids
+	GroupCTR map[string]float64
+}
+
+// Returns the true CTR of the banner in the group
+func (b Banner) CTRInGroup(groupID int) float64 {
+	if ctr, has := b.GroupCTR[strconv.Itoa(groupID)]; has {
+		return ctr
+	}
+
+	return b.CTR
+}
+
+// Group of users with its share of the traffic
+type Group struct {
+	ID     int
+	Weight float64
+}
+
+// Settings of the simulation
+type Options struct {
+	// Strategy of the slot and its parameters
+	Strategy   string
+	Parameters map[string]float64
+
+	// Number of banner selections
+	Views int
+
+	// Number of points of the convergence curve
+	Checkpoints int
+
+	// Seed of the clicks and the traffic, zero seeds it with one
+	Seed int64
+
+	Banners []Banner
+	Groups  []Group
+}
+
+// DecodeFile reads the settings of the simulation from the toml file
+func DecodeFile(path string) (Options, error) {
+	options := Options{
+		Strategy:    algorithm.DefaultStrategy,
+		Views:       10000,
+		Checkpoints: 10,
+	}
+
+	_, err := toml.DecodeFile(path, &options)
+
+	return options, err
+}
+
+// Validate checks the settings of the simulation
+func (o Options) Validate() error {
+	if len(o.Banners) == 0 {
+		return ErrBannersEmpty
+	}
+	if o.Views < 1 {
+		return ErrInvalidViews
+	}
+
+	for _, banner := range o.Banners {
+		for _, ctr := range append([]float64{banner.CTR}, values(banner.GroupCTR)...) {
+			if ctr < 0 || ctr > 1 {
+				return ErrInvalidCTR
+			}
+		}
+	}
+
+	total := 0.0
+	for _, group := range o.Groups {
+		if group.Weight < 0 {
+			return ErrInvalidWeights
+		}
+
+		total += group.Weight
+	}
+
+	if len(o.Groups) > 0 && total <= 0 {
+		return ErrInvalidWeights
+	}
+
+	return nil
+}
+
+// Point of the convergence curve
+type Checkpoint struct {
+	// Number of selections so far
+	Views int
+
+	// CTR of the selections since the previous point
+	CTR float64
+
+	// Share of the selections of the best banner of the group since the previous point
+	BestShare float64
+
+	// Expected clicks lost to the best banners of the groups so far
+	Regret float64
+}
+
+// Results of the simulation
+type Result struct {
+	Views  int
+	Clicks int
+
+	// Expected clicks lost to the best banners of the groups
+	Regret float64
+
+	// Share of the selections of the best banner of the group
+	BestShare float64
+
+	// Share of the selections of every banner
+	TrafficShare map[int]float64
+
+	Curve []Checkpoint
+}
+
+// Run drives the rotation service with the in-memory repositories through the synthetic traffic:
+// every view selects a banner for a group drawn by the weights and clicks it with its true CTR in the group
+func Run(ctx context.Context, options Options, strategies *algorithm.Registry) (*Result, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	groups := options.Groups
+	if len(groups) == 0 {
+		groups = []Group{{ID: 1, Weight: 1}}
+	}
+
+	seed := options.Seed
+	if seed == 0 {
+		seed = 1
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	statisticsRepository := memory.NewStatisticsRepository()
+	countersRepository := memory.NewCountersRepository()
+	rotationService := service.RotationService{
+		StatisticsService: &service.StatisticsService{
+			StatisticsRepository: statisticsRepository,
+			CountersRepository:   countersRepository,
+		},
+		RotationRepository:   memory.NewRotationRepository(),
+		StatisticsRepository: statisticsRepository,
+		CountersRepository:   countersRepository,
+		SlotRepository:       memory.NewSlotRepository(),
+		Strategies:           strategies,
+	}
+
+	_, err := rotationService.SetStrategy(ctx, slotID, options.Strategy, repository.Parameters(options.Parameters))
+	if err != nil {
+		return nil, err
+	}
+
+	banners := make(map[int]Banner, len(options.Banners))
+	rotations := make(map[int]repository.Rotation, len(options.Banners))
+
+	for _, banner := range options.Banners {
+		rotation, err := rotationService.Add(ctx, repository.Rotation{BannerID: banner.ID, SlotID: slotID})
+		if err != nil {
+			return nil, err
+		}
+
+		banners[banner.ID] = banner
+		rotations[banner.ID] = *rotation
+	}
+
+	checkpoints := options.Checkpoints
+	if checkpoints < 1 {
+		checkpoints = 1
+	}
+
+	result := &Result{
+		Views:        options.Views,
+		TrafficShare: make(map[int]float64, len(banners)),
+	}
+
+	best := 0
+	clicks := 0
+	views := 0
+
+	for i := 1; i <= options.Views; i++ {
+		group := draw(r, groups)
+
+		bannerID, _, err := rotationService.SelectBanner(ctx, slotID, group.ID, "", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		ctr := banners[bannerID].CTRInGroup(group.ID)
+		bestCTR := bestCTRInGroup(options.Banners, group.ID)
+
+		if ctr >= bestCTR {
+			best++
+			result.BestShare++
+		}
+
+		result.Regret += bestCTR - ctr
+		result.TrafficShare[bannerID]++
+		views++
+
+		if r.Float64() < ctr {
+			_, err = rotationService.SetTransition(ctx, rotations[bannerID], group.ID, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			clicks++
+			result.Clicks++
+		}
+
+		if i*checkpoints/options.Views != (i-1)*checkpoints/options.Views || i == options.Views {
+			result.Curve = append(result.Curve, Checkpoint{
+				Views:     i,
+				CTR:       float64(clicks) / float64(views),
+				BestShare: float64(best) / float64(views),
+				Regret:    result.Regret,
+			})
+
+			best, clicks, views = 0, 0, 0
+		}
+	}
+
+	result.BestShare /= float64(options.Views)
+	for bannerID := range result.TrafficShare {
+		result.TrafficShare[bannerID] /= float64(options.Views)
+	}
+
+	return result, nil
+}
+
+// Draws the group by the weights
+func draw(r *rand.Rand, groups []Group) Group {
+	total := 0.0
+	for _, group := range groups {
+		total += group.Weight
+	}
+
+	u := r.Float64() * total
+	for _, group := range groups {
+		u -= group.Weight
+		if u < 0 {
+			return group
+		}
+	}
+
+	return groups[len(groups)-1]
+}
+
+// Returns the true CTR of the best banner in the group
+func bestCTRInGroup(banners []Banner, groupID int) float64 {
+	best := 0.0
+	for _, banner := range banners {
+		if ctr := banner.CTRInGroup(groupID); ctr > best {
+			best = ctr
+		}
+	}
+
+	return best
+}
+
+// Returns the values of the map
+func values(m map[string]float64) []float64 {
+	result := make([]float64, 0, len(m))
+	for _, v := range m {
+		result = append(result, v)
+	}
+
+	return result
+}
@@ -0,0 +1,71 @@
+package simulation
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/algorithm"
+	"github.com/koind/banner-rotation/api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	banners := []Banner{{ID: 1, CTR: 0.1}}
+
+	testCases := []struct {
+		name    string
+		options Options
+		err     error
+	}{
+		{"correct options", Options{Views: 10, Banners: banners}, nil},
+		{"no banners", Options{Views: 10}, ErrBannersEmpty},
+		{"no views", Options{Banners: banners}, ErrInvalidViews},
+		{"invalid ctr", Options{Views: 10, Banners: []Banner{{ID: 1, CTR: 2}}}, ErrInvalidCTR},
+		{
+			"invalid group ctr",
+			Options{Views: 10, Banners: []Banner{{ID: 1, CTR: 0.1, GroupCTR: map[string]float64{"2": -1}}}},
+			ErrInvalidCTR,
+		},
+		{"zero weights", Options{Views: 10, Banners: banners, Groups: []Group{{ID: 1}}}, ErrInvalidWeights},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.err, testCase.options.Validate(), testCase.name)
+	}
+}
+
+func TestDecodeFile(t *testing.T) {
+	options, err := DecodeFile("../../config/development/simulation.toml")
+	assert.Nil(t, err)
+	assert.Nil(t, options.Validate())
+	assert.Equal(t, 0.08, options.Banners[2].CTRInGroup(2))
+	assert.Equal(t, 0.04, options.Banners[2].CTRInGroup(1))
+}
+
+func TestRun(t *testing.T) {
+	options := Options{
+		Strategy:    algorithm.StrategyThompson,
+		Views:       1000,
+		Checkpoints: 4,
+		Seed:        1,
+		Banners: []Banner{
+			{ID: 1, CTR: 0.05, GroupCTR: map[string]float64{"2": 0.5}},
+			{ID: 2, CTR: 0.5, GroupCTR: map[string]float64{"2": 0.05}},
+		},
+		Groups: []Group{{ID: 1, Weight: 1}, {ID: 2, Weight: 1}},
+	}
+
+	registry := algorithm.NewDefaultRegistry(algorithm.NewLockedSource(1), config.DefaultStrategies())
+
+	result, err := Run(context.Background(), options, registry)
+	assert.Nil(t, err)
+	assert.Len(t, result.Curve, 4)
+	assert.Equal(t, 1000, result.Curve[3].Views)
+	assert.Equal(t, result.Regret, result.Curve[3].Regret)
+	assert.True(t, result.BestShare > 0.8, "the best banner of every group should get the most of the traffic")
+	assert.True(t, result.Curve[3].BestShare > result.Curve[0].BestShare, "the strategy should converge")
+	assert.InDelta(t, 1, result.TrafficShare[1]+result.TrafficShare[2], 1e-9)
+
+	options.Strategy = "unknown"
+	_, err = Run(context.Background(), options, registry)
+	assert.Equal(t, algorithm.ErrUnknownStrategy, err)
+}
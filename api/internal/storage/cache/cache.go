@@ -0,0 +1,245 @@
+package cache
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Settings of the write-behind flushing
+type Options struct {
+	// Interval between the flushes
+	FlushInterval time.Duration
+
+	// Number of the pending statistics or counters that triggers the flush
+	FlushSize int
+
+	// Interval the cached counters, rotations and slots are loaded again from the backend after
+	// to pick up the changes of the other instances
+	RefreshInterval time.Duration
+}
+
+// In-process cache of the selection state with the write-behind flushing of the statistics and counters.
+// The views and clicks are applied to the cached counters at once and flushed to the backend in batches
+// in one transaction of the transactor, the cached state is kept across the flushes and loaded again
+// every refresh interval to pick up the changes of the other instances
+type Cache struct {
+	Statistics *StatisticsRepository
+	Counters   *CountersRepository
+	Rotations  *RotationRepository
+	Slots      *SlotRepository
+
+	options    Options
+	transactor repository.TransactorInterface
+	logger     zap.Logger
+
+	// Returns the current time, the refresh interval is measured by it
+	now func() time.Time
+
+	// Serializes the flushes
+	flushMu sync.Mutex
+
+	// Number of the started and finished flushes, odd while the flush writes to the backend.
+	// The loads from the backend do not block the flushes, they are repeated when a flush overlaps them
+	sequence uint64
+
+	// Guards the state of the flushing loop
+	mu      sync.Mutex
+	running bool
+	closed  bool
+
+	notify chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// Returns the cache over the backend repositories, without the transactor
+// the statistics and counters are flushed one after another
+func New(
+	options Options,
+	statistics repository.StatisticsRepositoryInterface,
+	counters repository.CountersRepositoryInterface,
+	rotations repository.RotationRepositoryInterface,
+	slots repository.SlotRepositoryInterface,
+	transactor repository.TransactorInterface,
+	logger zap.Logger,
+) *Cache {
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = time.Second
+	}
+
+	if options.RefreshInterval <= 0 {
+		options.RefreshInterval = time.Minute
+	}
+
+	c := &Cache{
+		options:    options,
+		transactor: transactor,
+		logger:     logger,
+		now:        time.Now,
+		notify:     make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	c.Statistics = &StatisticsRepository{cache: c, backend: statistics}
+	c.Counters = &CountersRepository{
+		cache:   c,
+		backend: counters,
+		views:   make(map[viewKey]*view),
+		since:   make(map[int]map[time.Time]bool),
+		pending: make(map[countersKey]repository.Counters),
+	}
+	c.Rotations = &RotationRepository{cache: c, backend: rotations, slots: make(map[int]*cachedRotations)}
+	c.Slots = &SlotRepository{cache: c, backend: slots, slots: make(map[int]*cachedSlot)}
+
+	return c
+}
+
+// Run flushes the cache every flush interval or when enough changes are pending until the cache is closed
+func (c *Cache) Run() {
+	c.mu.Lock()
+	if c.closed || c.running {
+		c.mu.Unlock()
+		return
+	}
+	c.running = true
+	c.mu.Unlock()
+
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.options.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		case <-c.notify:
+		}
+
+		if err := c.Flush(context.Background()); err != nil {
+			c.logger.Error("Error when flushing the cache", zap.Error(err))
+		}
+	}
+}
+
+// Close stops the flushing and flushes the pending changes
+func (c *Cache) Close(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.stop)
+	}
+	running := c.running
+	c.mu.Unlock()
+
+	if running {
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return c.Flush(ctx)
+}
+
+// Flush writes the pending statistics and counters to the backend and drops the cached state
+// loaded before the refresh interval, the changes that failed to be written are kept
+func (c *Cache) Flush(ctx context.Context) error {
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+
+	atomic.AddUint64(&c.sequence, 1)
+	defer atomic.AddUint64(&c.sequence, 1)
+
+	statisticsList := c.Statistics.take()
+	countersList := c.Counters.take()
+	written := false
+
+	err := c.withinTransaction(ctx, func(ctx context.Context) error {
+		if len(statisticsList) > 0 {
+			err := c.Statistics.backend.AddBatch(ctx, statisticsList...)
+			if err != nil {
+				return errors.Wrap(err, "error when flushing statistics")
+			}
+		}
+
+		written = true
+
+		if len(countersList) > 0 {
+			err := c.Counters.backend.Add(ctx, countersList...)
+			if err != nil {
+				return errors.Wrap(err, "error when flushing counters")
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		// the transaction rolls back the written statistics
+		if !written || c.transactor != nil {
+			c.Statistics.restore(statisticsList)
+		}
+
+		c.Counters.restore(countersList)
+
+		return err
+	}
+
+	c.Counters.evict()
+	c.Rotations.evict()
+	c.Slots.evict()
+
+	return nil
+}
+
+// Returns the sequence of the flushes when no flush writes to the backend, waits for the flush in progress
+func (c *Cache) settled() uint64 {
+	for {
+		sequence := atomic.LoadUint64(&c.sequence)
+		if sequence%2 == 0 {
+			return sequence
+		}
+
+		c.flushMu.Lock()
+		c.flushMu.Unlock()
+	}
+}
+
+// Reports whether no flush started since the sequence was settled
+func (c *Cache) unchanged(sequence uint64) bool {
+	return atomic.LoadUint64(&c.sequence) == sequence
+}
+
+// Reports whether the refresh interval passed since the state was loaded
+func (c *Cache) expired(loadedAt time.Time) bool {
+	return c.now().Sub(loadedAt) >= c.options.RefreshInterval
+}
+
+// Runs the function in one transaction of the transactor, without the transactor runs it as is
+func (c *Cache) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if c.transactor == nil {
+		return fn(ctx)
+	}
+
+	return c.transactor.WithinTransaction(ctx, fn)
+}
+
+// Triggers the flush when the number of pending changes reaches the flush size
+func (c *Cache) pending(n int) {
+	if c.options.FlushSize <= 0 || n < c.options.FlushSize {
+		return
+	}
+
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
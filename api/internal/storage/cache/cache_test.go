@@ -0,0 +1,309 @@
+package cache
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/koind/banner-rotation/api/internal/storage/memory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+// Counters backend counting the searches
+type countingCounters struct {
+	*memory.CountersRepository
+	searches int
+	failing  bool
+}
+
+func (c *countingCounters) Add(ctx context.Context, counters ...repository.Counters) error {
+	if c.failing {
+		return errors.New("backend is down")
+	}
+
+	return c.CountersRepository.Add(ctx, counters...)
+}
+
+func (c *countingCounters) FindAllBySlotIDAndGroupID(
+	ctx context.Context,
+	slotID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	c.searches++
+
+	return c.CountersRepository.FindAllBySlotIDAndGroupID(ctx, slotID, groupID)
+}
+
+func (c *countingCounters) FindTotalsBySlotIDAndGroupID(
+	ctx context.Context,
+	slotID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	c.searches++
+
+	return c.CountersRepository.FindTotalsBySlotIDAndGroupID(ctx, slotID, groupID)
+}
+
+func (c *countingCounters) FindTotalsByBannerIDAndGroupID(
+	ctx context.Context,
+	bannerID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	c.searches++
+
+	return c.CountersRepository.FindTotalsByBannerIDAndGroupID(ctx, bannerID, groupID)
+}
+
+func (c *countingCounters) FindAllBySlotIDSince(
+	ctx context.Context,
+	slotID int,
+	since time.Time,
+) ([]*repository.Counters, error) {
+	c.searches++
+
+	return c.CountersRepository.FindAllBySlotIDSince(ctx, slotID, since)
+}
+
+// Returns the cache over the memory repositories with the clock that is moved by hand
+func newTestCache(counters repository.CountersRepositoryInterface) (*Cache, *time.Time) {
+	c := New(
+		Options{FlushInterval: time.Hour, RefreshInterval: time.Minute},
+		memory.NewStatisticsRepository(),
+		counters,
+		memory.NewRotationRepository(),
+		memory.NewSlotRepository(),
+		nil,
+		*zap.NewNop(),
+	)
+
+	clock := time.Now()
+	c.now = func() time.Time {
+		return clock
+	}
+
+	return c, &clock
+}
+
+// Returns the views of the counters
+func views(countersList []*repository.Counters) int {
+	views := 0
+	for _, counters := range countersList {
+		views += counters.Views
+	}
+
+	return views
+}
+
+func TestCountersRepository_KeepsStateAcrossFlushes(t *testing.T) {
+	backend := &countingCounters{CountersRepository: memory.NewCountersRepository()}
+	c, clock := newTestCache(backend)
+
+	ctx := context.Background()
+	period := time.Now().UTC().Truncate(repository.CountersPeriod)
+
+	backend.Add(ctx, repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: period, Views: 10})
+
+	countersList, err := c.Counters.FindTotalsBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, views(countersList))
+
+	c.Counters.Add(ctx, repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: period, Views: 1})
+	assert.Nil(t, c.Flush(ctx))
+
+	countersList, err = c.Counters.FindTotalsBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 11, views(countersList))
+	assert.Equal(t, 1, backend.searches, "counters should be kept across the flushes")
+
+	// the views of another instance
+	backend.Add(ctx, repository.Counters{SlotID: 1, GroupID: 1, BannerID: 2, Period: period, Views: 5})
+	c.Counters.Add(ctx, repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: period, Views: 1})
+
+	*clock = clock.Add(time.Minute)
+
+	countersList, err = c.Counters.FindTotalsBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 17, views(countersList), "counters should be loaded again after the refresh interval")
+	assert.Equal(t, 2, backend.searches)
+}
+
+func TestCountersRepository_CachesSearches(t *testing.T) {
+	backend := &countingCounters{CountersRepository: memory.NewCountersRepository()}
+	c, _ := newTestCache(backend)
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	period := now.Truncate(repository.CountersPeriod)
+	dayAgo := period.Add(-24 * time.Hour)
+
+	backend.Add(
+		ctx,
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: dayAgo, Views: 10},
+		repository.Counters{SlotID: 2, GroupID: 1, BannerID: 1, Period: period, Views: 20},
+	)
+
+	since, err := c.Counters.FindAllBySlotIDSince(ctx, 1, now.Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, views(since))
+
+	banner, err := c.Counters.FindTotalsByBannerIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 30, views(banner))
+
+	c.Counters.Add(
+		ctx,
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: period, Views: 1},
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: dayAgo, Views: 2},
+		repository.Counters{SlotID: 1, GroupID: 2, BannerID: 1, Period: period, Views: 4},
+	)
+
+	since, err = c.Counters.FindAllBySlotIDSince(ctx, 1, now.Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 5, views(since), "views since the period should be added in all the groups")
+
+	banner, err = c.Counters.FindTotalsByBannerIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 33, views(banner), "views of the banner should be added in all the slots of the group")
+
+	for _, counters := range banner {
+		assert.True(t, counters.Period.IsZero(), "totals should have no period")
+	}
+
+	assert.Equal(t, 2, backend.searches, "cached searches should not query the backend")
+}
+
+func TestCountersRepository_RemoveBefore(t *testing.T) {
+	backend := &countingCounters{CountersRepository: memory.NewCountersRepository()}
+	c, _ := newTestCache(backend)
+
+	ctx := context.Background()
+	period := time.Now().UTC().Truncate(repository.CountersPeriod)
+	monthAgo := period.AddDate(0, -1, 0)
+
+	backend.Add(
+		ctx,
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: monthAgo, Views: 10},
+		repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: period, Views: 1},
+	)
+
+	c.Counters.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	c.Counters.FindTotalsBySlotIDAndGroupID(ctx, 1, 1)
+
+	assert.Nil(t, c.Counters.RemoveBefore(ctx, period.Add(-time.Hour)))
+
+	countersList, err := c.Counters.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, views(countersList), "cached counters before the time should be removed")
+
+	totals, err := c.Counters.FindTotalsBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 11, views(totals), "totals should be kept")
+}
+
+func TestCache_FlushKeepsFailedChanges(t *testing.T) {
+	backend := &countingCounters{CountersRepository: memory.NewCountersRepository(), failing: true}
+	c, _ := newTestCache(backend)
+
+	ctx := context.Background()
+	period := time.Now().UTC().Truncate(repository.CountersPeriod)
+
+	statistics, err := c.Statistics.Add(ctx, repository.Statistics{Type: repository.StatisticsTypeView, SlotID: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, statistics.ID, "buffered statistics should have no id")
+
+	c.Counters.Add(ctx, repository.Counters{SlotID: 1, GroupID: 1, BannerID: 1, Period: period, Views: 1})
+
+	assert.NotNil(t, c.Flush(ctx))
+
+	countersList, err := c.Counters.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, views(countersList), "changes that failed to be written should stay pending")
+
+	backend.failing = false
+	assert.Nil(t, c.Flush(ctx))
+
+	countersList, err = backend.FindAllBySlotIDAndGroupID(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, views(countersList))
+
+	statisticsList, err := c.Statistics.FindAllBySlotIDAndGroupID(ctx, 1, 0)
+	assert.Nil(t, err)
+	assert.Len(t, statisticsList, 1, "statistics should be written once")
+}
+
+func TestRotationRepository_KeepsRotationsAcrossFlushes(t *testing.T) {
+	rotations := memory.NewRotationRepository()
+	c := New(
+		Options{FlushInterval: time.Hour, RefreshInterval: time.Minute},
+		memory.NewStatisticsRepository(),
+		memory.NewCountersRepository(),
+		rotations,
+		memory.NewSlotRepository(),
+		nil,
+		*zap.NewNop(),
+	)
+
+	clock := time.Now()
+	c.now = func() time.Time {
+		return clock
+	}
+
+	ctx := context.Background()
+	c.Rotations.Add(ctx, repository.Rotation{BannerID: 1, SlotID: 1})
+
+	cached, err := c.Rotations.FindAllBySlotID(ctx, 1)
+	assert.Nil(t, err)
+	assert.Len(t, cached, 1)
+
+	// the rotation of another instance
+	rotations.Add(ctx, repository.Rotation{BannerID: 2, SlotID: 1})
+	assert.Nil(t, c.Flush(ctx))
+
+	cached, err = c.Rotations.FindAllBySlotID(ctx, 1)
+	assert.Nil(t, err)
+	assert.Len(t, cached, 1, "rotations should be kept across the flushes")
+
+	clock = clock.Add(time.Minute)
+
+	cached, err = c.Rotations.FindAllBySlotID(ctx, 1)
+	assert.Nil(t, err)
+	assert.Len(t, cached, 2, "rotations should be loaded again after the refresh interval")
+
+	c.Rotations.Add(ctx, repository.Rotation{BannerID: 3, SlotID: 1})
+
+	cached, err = c.Rotations.FindAllBySlotID(ctx, 1)
+	assert.Nil(t, err)
+	assert.Len(t, cached, 3, "rotations of the slot should be loaded again after they change")
+}
+
+func TestCache_SettingsAreCopied(t *testing.T) {
+	c, _ := newTestCache(memory.NewCountersRepository())
+
+	ctx := context.Background()
+	enabled := true
+	c.Rotations.Add(ctx, repository.Rotation{
+		BannerID:   1,
+		SlotID:     1,
+		Dayparting: repository.Dayparting{{Weekdays: []time.Weekday{time.Monday}, From: 9, To: 18}},
+		Targeting:  &repository.Targeting{Groups: []int{1}},
+	})
+	c.Slots.Save(ctx, repository.Slot{ID: 1, Parameters: repository.Parameters{"alpha": 1}, Enabled: &enabled})
+
+	rotations, _ := c.Rotations.FindAllBySlotID(ctx, 1)
+	rotations[0].Dayparting[0].Weekdays[0] = time.Sunday
+	rotations[0].Targeting.Groups[0] = 2
+
+	slot, _ := c.Slots.FindOneByID(ctx, 1)
+	slot.Parameters["alpha"] = 2
+	*slot.Enabled = false
+
+	rotations, _ = c.Rotations.FindAllBySlotID(ctx, 1)
+	assert.Equal(t, time.Monday, rotations[0].Dayparting[0].Weekdays[0])
+	assert.Equal(t, []int{1}, rotations[0].Targeting.Groups)
+
+	slot, _ = c.Slots.FindOneByID(ctx, 1)
+	assert.Equal(t, 1.0, slot.Parameters["alpha"])
+	assert.True(t, slot.IsEnabled())
+}
@@ -0,0 +1,382 @@
+package cache
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+const (
+	// Hourly counters of the slot and group
+	viewHourly = iota
+
+	// Totals of the slot and group
+	viewTotals
+
+	// Totals of the banner in all the slots by group
+	viewBannerTotals
+
+	// Totals of the slot in all the groups
+	viewSlotTotals
+
+	// Hourly counters of the slot in all the groups since the period
+	viewSince
+)
+
+// Key of the cached counters of one search
+type viewKey struct {
+	kind     int
+	slotID   int
+	groupID  int
+	bannerID int
+	since    time.Time
+}
+
+// Returns the change as the counters of the key count it, reports false when they do not count it
+func (k viewKey) match(delta repository.Counters) (repository.Counters, bool) {
+	switch k.kind {
+	case viewHourly:
+		return delta, delta.SlotID == k.slotID && delta.GroupID == k.groupID
+	case viewTotals:
+		return delta.Total(), delta.SlotID == k.slotID && delta.GroupID == k.groupID
+	case viewBannerTotals:
+		return delta.Total(), delta.BannerID == k.bannerID && delta.GroupID == k.groupID
+	case viewSlotTotals:
+		return delta.Total(), delta.SlotID == k.slotID
+	case viewSince:
+		return delta, delta.SlotID == k.slotID && !delta.Period.Before(k.since)
+	}
+
+	return delta, false
+}
+
+// Counters of one search loaded from the backend with the changes applied since
+type view struct {
+	counters map[countersKey]repository.Counters
+	loadedAt time.Time
+}
+
+// Key of the counters
+type countersKey struct {
+	slotID   int
+	groupID  int
+	bannerID int
+	period   time.Time
+	position int
+}
+
+// Returns the key of the counters
+func keyOf(counters repository.Counters) countersKey {
+	return countersKey{counters.SlotID, counters.GroupID, counters.BannerID, counters.Period, counters.Position}
+}
+
+// Cached counters repository with the write-behind flushing
+type CountersRepository struct {
+	sync.Mutex
+	cache   *Cache
+	backend repository.CountersRepositoryInterface
+
+	// Counters of the searches with the changes applied, they are kept across the flushes
+	// and loaded again after the refresh interval
+	views map[viewKey]*view
+
+	// Periods of the cached counters of the slots since the period
+	since map[int]map[time.Time]bool
+
+	// Changes that are not flushed yet
+	pending map[countersKey]repository.Counters
+}
+
+// Adds the counters to the cached counters, the changes are flushed later
+func (c *CountersRepository) Add(ctx context.Context, counters ...repository.Counters) error {
+	c.Lock()
+
+	for _, delta := range counters {
+		merge(c.pending, keyOf(delta), delta)
+
+		for _, key := range c.keys(delta) {
+			if matched, has := key.match(delta); has {
+				merge(c.views[key].counters, keyOf(matched), matched)
+			}
+		}
+	}
+
+	n := len(c.pending)
+	c.Unlock()
+
+	c.cache.pending(n)
+
+	return nil
+}
+
+// Find all the counters by slot and group
+func (c *CountersRepository) FindAllBySlotIDAndGroupID(
+	ctx context.Context,
+	slotID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	return c.find(
+		ctx,
+		viewKey{kind: viewHourly, slotID: slotID, groupID: groupID},
+		func(ctx context.Context) ([]*repository.Counters, error) {
+			return c.backend.FindAllBySlotIDAndGroupID(ctx, slotID, groupID)
+		},
+	)
+}
+
+// Find the totals of the counters by slot and group, the totals have no period
+func (c *CountersRepository) FindTotalsBySlotIDAndGroupID(
+	ctx context.Context,
+	slotID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	return c.find(
+		ctx,
+		viewKey{kind: viewTotals, slotID: slotID, groupID: groupID},
+		func(ctx context.Context) ([]*repository.Counters, error) {
+			return c.backend.FindTotalsBySlotIDAndGroupID(ctx, slotID, groupID)
+		},
+	)
+}
+
+// Find the totals of the counters of the slot in all the groups
+func (c *CountersRepository) FindTotalsBySlotID(ctx context.Context, slotID int) ([]*repository.Counters, error) {
+	return c.find(
+		ctx,
+		viewKey{kind: viewSlotTotals, slotID: slotID},
+		func(ctx context.Context) ([]*repository.Counters, error) {
+			return c.backend.FindTotalsBySlotID(ctx, slotID)
+		},
+	)
+}
+
+// Find the totals of the counters of the banner in all the slots by group
+func (c *CountersRepository) FindTotalsByBannerIDAndGroupID(
+	ctx context.Context,
+	bannerID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	return c.find(
+		ctx,
+		viewKey{kind: viewBannerTotals, bannerID: bannerID, groupID: groupID},
+		func(ctx context.Context) ([]*repository.Counters, error) {
+			return c.backend.FindTotalsByBannerIDAndGroupID(ctx, bannerID, groupID)
+		},
+	)
+}
+
+// Find all the counters of the slot in all the groups from the period of the time
+func (c *CountersRepository) FindAllBySlotIDSince(
+	ctx context.Context,
+	slotID int,
+	since time.Time,
+) ([]*repository.Counters, error) {
+	period := since.Truncate(repository.CountersPeriod)
+
+	return c.find(
+		ctx,
+		viewKey{kind: viewSince, slotID: slotID, since: period},
+		func(ctx context.Context) ([]*repository.Counters, error) {
+			return c.backend.FindAllBySlotIDSince(ctx, slotID, period)
+		},
+	)
+}
+
+// Removes the counters of the periods before the time, the totals are kept.
+// The pending changes of the removed periods are still written at the flush
+func (c *CountersRepository) RemoveBefore(ctx context.Context, before time.Time) error {
+	err := c.backend.RemoveBefore(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	period := before.Truncate(repository.CountersPeriod)
+
+	c.Lock()
+	defer c.Unlock()
+
+	for key, v := range c.views {
+		if key.kind != viewHourly && key.kind != viewSince {
+			continue
+		}
+
+		for k := range v.counters {
+			if k.period.Before(period) {
+				delete(v.counters, k)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the cached counters of the key, loads them from the backend when they are not cached
+// or the refresh interval passed since they were loaded
+func (c *CountersRepository) find(
+	ctx context.Context,
+	key viewKey,
+	find func(ctx context.Context) ([]*repository.Counters, error),
+) ([]*repository.Counters, error) {
+	c.Lock()
+	v, has := c.views[key]
+	if has && !c.cache.expired(v.loadedAt) {
+		countersList := list(v.counters)
+		c.Unlock()
+
+		return countersList, nil
+	}
+	c.Unlock()
+
+	return c.load(ctx, key, find)
+}
+
+// Returns the counters of the backend with the pending changes the key counts applied and caches them.
+// The backend is queried without blocking the flushes, the load is repeated when the pending changes
+// are flushed in the meantime
+func (c *CountersRepository) load(
+	ctx context.Context,
+	key viewKey,
+	find func(ctx context.Context) ([]*repository.Counters, error),
+) ([]*repository.Counters, error) {
+	for {
+		sequence := c.cache.settled()
+		loadedAt := c.cache.now()
+
+		stored, err := find(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Lock()
+
+		if !c.cache.unchanged(sequence) {
+			c.Unlock()
+			continue
+		}
+
+		counters := make(map[countersKey]repository.Counters, len(stored))
+		for _, s := range stored {
+			merge(counters, keyOf(*s), *s)
+		}
+
+		for _, delta := range c.pending {
+			if matched, has := key.match(delta); has {
+				merge(counters, keyOf(matched), matched)
+			}
+		}
+
+		c.views[key] = &view{counters: counters, loadedAt: loadedAt}
+		if key.kind == viewSince {
+			if c.since[key.slotID] == nil {
+				c.since[key.slotID] = make(map[time.Time]bool)
+			}
+
+			c.since[key.slotID][key.since] = true
+		}
+
+		countersList := list(counters)
+		c.Unlock()
+
+		return countersList, nil
+	}
+}
+
+// Takes the pending changes to write them to the backend
+func (c *CountersRepository) take() []repository.Counters {
+	c.Lock()
+	pending := c.pending
+	c.pending = make(map[countersKey]repository.Counters)
+	c.Unlock()
+
+	countersList := make([]repository.Counters, 0, len(pending))
+	for _, delta := range pending {
+		countersList = append(countersList, delta)
+	}
+
+	return countersList
+}
+
+// Puts the changes that failed to be written back to the pending changes
+func (c *CountersRepository) restore(countersList []repository.Counters) {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, delta := range countersList {
+		merge(c.pending, keyOf(delta), delta)
+	}
+}
+
+// Drops the counters loaded before the refresh interval, they are loaded again on the next search
+func (c *CountersRepository) evict() {
+	c.Lock()
+	defer c.Unlock()
+
+	for key, v := range c.views {
+		if !c.cache.expired(v.loadedAt) {
+			continue
+		}
+
+		delete(c.views, key)
+		if key.kind != viewSince {
+			continue
+		}
+
+		delete(c.since[key.slotID], key.since)
+		if len(c.since[key.slotID]) == 0 {
+			delete(c.since, key.slotID)
+		}
+	}
+}
+
+// Returns the keys of the cached counters that may count the change
+func (c *CountersRepository) keys(delta repository.Counters) []viewKey {
+	candidates := []viewKey{
+		{kind: viewHourly, slotID: delta.SlotID, groupID: delta.GroupID},
+		{kind: viewTotals, slotID: delta.SlotID, groupID: delta.GroupID},
+		{kind: viewBannerTotals, bannerID: delta.BannerID, groupID: delta.GroupID},
+		{kind: viewSlotTotals, slotID: delta.SlotID},
+	}
+
+	for since := range c.since[delta.SlotID] {
+		candidates = append(candidates, viewKey{kind: viewSince, slotID: delta.SlotID, since: since})
+	}
+
+	keys := make([]viewKey, 0, len(candidates))
+	for _, key := range candidates {
+		if _, has := c.views[key]; has {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// Adds the delta to the counters of the key
+func merge(counters map[countersKey]repository.Counters, key countersKey, delta repository.Counters) {
+	stored, has := counters[key]
+	if !has {
+		stored = repository.Counters{
+			SlotID:   key.slotID,
+			GroupID:  key.groupID,
+			BannerID: key.bannerID,
+			Period:   key.period,
+			Position: key.position,
+		}
+	}
+
+	stored.Merge(delta)
+	counters[key] = stored
+}
+
+// Returns the copies of the counters
+func list(counters map[countersKey]repository.Counters) []*repository.Counters {
+	countersList := make([]*repository.Counters, 0, len(counters))
+
+	for _, c := range counters {
+		c := c
+		countersList = append(countersList, &c)
+	}
+
+	return countersList
+}
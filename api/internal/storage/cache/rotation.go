@@ -0,0 +1,196 @@
+package cache
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+// Rotations of the slot loaded from the backend
+type cachedRotations struct {
+	rotations []*repository.Rotation
+	loadedAt  time.Time
+}
+
+// Rotation repository caching the rotations of the slots for the refresh interval
+type RotationRepository struct {
+	sync.RWMutex
+	cache   *Cache
+	backend repository.RotationRepositoryInterface
+	slots   map[int]*cachedRotations
+
+	// Number of the changes of the rotations, the rotations loaded while they change are not cached
+	version uint64
+}
+
+// Adds a new banner to the rotation in this slot
+func (r *RotationRepository) Add(ctx context.Context, rotation repository.Rotation) (*repository.Rotation, error) {
+	newRotation, err := r.backend.Add(ctx, rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	r.forget(rotation.SlotID)
+
+	return newRotation, nil
+}
+
+// Find one rotation by banner id
+func (r *RotationRepository) FindOneByBannerID(ctx context.Context, bannerID int) (*repository.Rotation, error) {
+	return r.backend.FindOneByBannerID(ctx, bannerID)
+}
+
+// Find all rotations by slot id
+func (r *RotationRepository) FindAllBySlotID(ctx context.Context, slotID int) ([]*repository.Rotation, error) {
+	r.RLock()
+	cached, has := r.slots[slotID]
+	version := r.version
+	r.RUnlock()
+
+	if has && !r.cache.expired(cached.loadedAt) {
+		return copyRotations(cached.rotations), nil
+	}
+
+	loadedAt := r.cache.now()
+
+	rotations, err := r.backend.FindAllBySlotID(ctx, slotID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Lock()
+	if r.version == version {
+		r.slots[slotID] = &cachedRotations{rotations: rotations, loadedAt: loadedAt}
+	}
+	r.Unlock()
+
+	return copyRotations(rotations), nil
+}
+
+// Updates the settings of the rotation of the banner
+func (r *RotationRepository) Update(ctx context.Context, rotation repository.Rotation) (*repository.Rotation, error) {
+	updated, err := r.backend.Update(ctx, rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	r.forget(updated.SlotID)
+
+	return updated, nil
+}
+
+// Removes the banner from the rotation
+func (r *RotationRepository) Remove(ctx context.Context, bannerID int) error {
+	err := r.backend.Remove(ctx, bannerID)
+	if err != nil {
+		return err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	// the slot of the banner is unknown here
+	r.slots = make(map[int]*cachedRotations)
+	r.version++
+
+	return nil
+}
+
+// Drops the cached rotations of the slot after they changed
+func (r *RotationRepository) forget(slotID int) {
+	r.Lock()
+	defer r.Unlock()
+
+	delete(r.slots, slotID)
+	r.version++
+}
+
+// Drops the rotations loaded before the refresh interval
+func (r *RotationRepository) evict() {
+	r.Lock()
+	defer r.Unlock()
+
+	for slotID, cached := range r.slots {
+		if r.cache.expired(cached.loadedAt) {
+			delete(r.slots, slotID)
+		}
+	}
+}
+
+// Returns the deep copies of the rotations
+func copyRotations(rotations []*repository.Rotation) []*repository.Rotation {
+	if rotations == nil {
+		return nil
+	}
+
+	copies := make([]*repository.Rotation, 0, len(rotations))
+
+	for _, rotation := range rotations {
+		copies = append(copies, copyRotation(rotation))
+	}
+
+	return copies
+}
+
+// Returns the deep copy of the rotation, the caller may change the schedule, dayparting and targeting of the copy
+func copyRotation(rotation *repository.Rotation) *repository.Rotation {
+	copied := *rotation
+	copied.StartsAt = copyTime(rotation.StartsAt)
+	copied.EndsAt = copyTime(rotation.EndsAt)
+
+	if rotation.Dayparting != nil {
+		copied.Dayparting = make(repository.Dayparting, 0, len(rotation.Dayparting))
+		for _, rule := range rotation.Dayparting {
+			if rule.Weekdays != nil {
+				rule.Weekdays = append([]time.Weekday{}, rule.Weekdays...)
+			}
+
+			copied.Dayparting = append(copied.Dayparting, rule)
+		}
+	}
+
+	if rotation.Targeting != nil {
+		targeting := *rotation.Targeting
+		targeting.Groups = copyInts(targeting.Groups)
+		targeting.DeniedGroups = copyInts(targeting.DeniedGroups)
+		targeting.Countries = copyStrings(targeting.Countries)
+		targeting.DeniedCountries = copyStrings(targeting.DeniedCountries)
+		targeting.Devices = copyStrings(targeting.Devices)
+		targeting.DeniedDevices = copyStrings(targeting.DeniedDevices)
+		targeting.Languages = copyStrings(targeting.Languages)
+		targeting.DeniedLanguages = copyStrings(targeting.DeniedLanguages)
+		copied.Targeting = &targeting
+	}
+
+	return &copied
+}
+
+// Returns the copy of the time, nil when the time is not set
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	copied := *t
+
+	return &copied
+}
+
+// Returns the copy of the numbers, nil stays nil
+func copyInts(values []int) []int {
+	if values == nil {
+		return nil
+	}
+
+	return append([]int{}, values...)
+}
+
+// Returns the copy of the strings, nil stays nil
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	return append([]string{}, values...)
+}
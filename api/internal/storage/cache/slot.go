@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+// Settings of the slot loaded from the backend, nil when the slot has no settings
+type cachedSlot struct {
+	slot     *repository.Slot
+	loadedAt time.Time
+}
+
+// Slot repository caching the settings of the slots for the refresh interval
+type SlotRepository struct {
+	sync.RWMutex
+	cache   *Cache
+	backend repository.SlotRepositoryInterface
+	slots   map[int]*cachedSlot
+
+	// Number of the changes of the slots, the slots loaded while they change are not cached
+	version uint64
+}
+
+// Saves the slot settings
+func (s *SlotRepository) Save(ctx context.Context, slot repository.Slot) (*repository.Slot, error) {
+	saved, err := s.backend.Save(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	s.forget(slot.ID)
+
+	return saved, nil
+}
+
+// Find one slot by id, returns nil if the slot has no settings
+func (s *SlotRepository) FindOneByID(ctx context.Context, slotID int) (*repository.Slot, error) {
+	s.RLock()
+	cached, has := s.slots[slotID]
+	version := s.version
+	s.RUnlock()
+
+	if !has || s.cache.expired(cached.loadedAt) {
+		loadedAt := s.cache.now()
+
+		slot, err := s.backend.FindOneByID(ctx, slotID)
+		if err != nil {
+			return nil, err
+		}
+
+		cached = &cachedSlot{slot: slot, loadedAt: loadedAt}
+
+		s.Lock()
+		if s.version == version {
+			s.slots[slotID] = cached
+		}
+		s.Unlock()
+	}
+
+	if cached.slot == nil {
+		return nil, nil
+	}
+
+	return copySlot(cached.slot), nil
+}
+
+// Find all the slots with settings ordered by id
+func (s *SlotRepository) FindAll(ctx context.Context) ([]*repository.Slot, error) {
+	return s.backend.FindAll(ctx)
+}
+
+// Removes the settings of the slot
+func (s *SlotRepository) Remove(ctx context.Context, slotID int) error {
+	err := s.backend.Remove(ctx, slotID)
+	if err != nil {
+		return err
+	}
+
+	s.forget(slotID)
+
+	return nil
+}
+
+// Drops the cached settings of the slot after they changed
+func (s *SlotRepository) forget(slotID int) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.slots, slotID)
+	s.version++
+}
+
+// Drops the slots loaded before the refresh interval
+func (s *SlotRepository) evict() {
+	s.Lock()
+	defer s.Unlock()
+
+	for slotID, cached := range s.slots {
+		if s.cache.expired(cached.loadedAt) {
+			delete(s.slots, slotID)
+		}
+	}
+}
+
+// Returns the deep copy of the slot, the caller may change the parameters and the enabled flag of the copy
+func copySlot(slot *repository.Slot) *repository.Slot {
+	copied := *slot
+
+	if slot.Parameters != nil {
+		copied.Parameters = make(repository.Parameters, len(slot.Parameters))
+		for name, value := range slot.Parameters {
+			copied.Parameters[name] = value
+		}
+	}
+
+	if slot.Enabled != nil {
+		enabled := *slot.Enabled
+		copied.Enabled = &enabled
+	}
+
+	return &copied
+}
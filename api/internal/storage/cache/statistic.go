@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"sync"
+)
+
+// Cached statistics repository with the write-behind flushing
+type StatisticsRepository struct {
+	sync.Mutex
+	cache   *Cache
+	backend repository.StatisticsRepositoryInterface
+
+	// Statistics that are not flushed yet
+	pending []repository.Statistics
+}
+
+// Adds the statistics to the buffer, the statistics are flushed later
+// and have no id until then
+func (s *StatisticsRepository) Add(
+	ctx context.Context,
+	statistics repository.Statistics,
+) (*repository.Statistics, error) {
+	s.Lock()
+	s.pending = append(s.pending, statistics)
+	n := len(s.pending)
+	s.Unlock()
+
+	s.cache.pending(n)
+
+	return &statistics, nil
+}
+
+// Adds the statistics to the buffer in one batch
+func (s *StatisticsRepository) AddBatch(ctx context.Context, statisticsList ...repository.Statistics) error {
+	s.Lock()
+	s.pending = append(s.pending, statisticsList...)
+	n := len(s.pending)
+	s.Unlock()
+
+	s.cache.pending(n)
+
+	return nil
+}
+
+// Find all the statistics by slot and group with the buffered statistics,
+// the search is repeated when the buffered statistics are flushed in the meantime
+func (s *StatisticsRepository) FindAllBySlotIDAndGroupID(
+	ctx context.Context,
+	slotID int,
+	groupID int,
+) ([]*repository.Statistics, error) {
+	for {
+		sequence := s.cache.settled()
+
+		statisticsList, err := s.backend.FindAllBySlotIDAndGroupID(ctx, slotID, groupID)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Lock()
+
+		if !s.cache.unchanged(sequence) {
+			s.Unlock()
+			continue
+		}
+
+		for _, statistics := range s.pending {
+			if statistics.SlotID == slotID && statistics.GroupID == groupID {
+				statistics := statistics
+				statisticsList = append(statisticsList, &statistics)
+			}
+		}
+
+		s.Unlock()
+
+		return statisticsList, nil
+	}
+}
+
+// Removes statistics
+func (s *StatisticsRepository) Remove(ctx context.Context, ID int) error {
+	return s.backend.Remove(ctx, ID)
+}
+
+// Takes the buffered statistics to write them to the backend
+func (s *StatisticsRepository) take() []repository.Statistics {
+	s.Lock()
+	defer s.Unlock()
+
+	pending := s.pending
+	s.pending = nil
+
+	return pending
+}
+
+// Puts the statistics that failed to be written back to the buffer
+func (s *StatisticsRepository) restore(statisticsList []repository.Statistics) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.pending = append(statisticsList, s.pending...)
+}
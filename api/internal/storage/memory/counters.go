@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+// Key of the counters
+type countersKey struct {
+	slotID   int
+	groupID  int
+	bannerID int
+	period   time.Time
+	position int
+}
+
+// Memory counters repository
+type CountersRepository struct {
+	sync.RWMutex
+	DB map[countersKey]repository.Counters
+
+	// Totals of the counters without the period
+	Totals map[countersKey]repository.Counters
+}
+
+// Will return new memory counters repository
+func NewCountersRepository() *CountersRepository {
+	return &CountersRepository{
+		DB:     make(map[countersKey]repository.Counters),
+		Totals: make(map[countersKey]repository.Counters),
+	}
+}
+
+// Adds the counters to the stored counters of the same slot, group, banner, period and position
+// and to the totals of the same slot, group, banner and position
+func (c *CountersRepository) Add(ctx context.Context, counters ...repository.Counters) error {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, delta := range counters {
+		merge(c.DB, delta)
+		merge(c.Totals, delta.Total())
+	}
+
+	return nil
+}
+
+// Find all the counters by slot and group
+func (c *CountersRepository) FindAllBySlotIDAndGroupID(
+	ctx context.Context,
+	slotID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	countersList := make([]*repository.Counters, 0)
+
+	for _, counters := range c.DB {
+		if counters.SlotID == slotID && counters.GroupID == groupID {
+			counters := counters
+			countersList = append(countersList, &counters)
+		}
+	}
+
+	return countersList, nil
+}
+
+// Find the totals of the counters by slot and group, the totals have no period
+func (c *CountersRepository) FindTotalsBySlotIDAndGroupID(
+	ctx context.Context,
+	slotID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	countersList := make([]*repository.Counters, 0)
+
+	for _, counters := range c.Totals {
+		if counters.SlotID == slotID && counters.GroupID == groupID {
+			counters := counters
+			countersList = append(countersList, &counters)
+		}
+	}
+
+	return countersList, nil
+}
+
+// Find the totals of the counters of the slot in all the groups
+func (c *CountersRepository) FindTotalsBySlotID(ctx context.Context, slotID int) ([]*repository.Counters, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	countersList := make([]*repository.Counters, 0)
+
+	for _, counters := range c.Totals {
+		if counters.SlotID == slotID {
+			counters := counters
+			countersList = append(countersList, &counters)
+		}
+	}
+
+	return countersList, nil
+}
+
+// Find the totals of the counters of the banner in all the slots by group
+func (c *CountersRepository) FindTotalsByBannerIDAndGroupID(
+	ctx context.Context,
+	bannerID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	countersList := make([]*repository.Counters, 0)
+
+	for _, counters := range c.Totals {
+		if counters.BannerID == bannerID && counters.GroupID == groupID {
+			counters := counters
+			countersList = append(countersList, &counters)
+		}
+	}
+
+	return countersList, nil
+}
+
+// Find all the counters of the slot in all the groups from the period of the time
+func (c *CountersRepository) FindAllBySlotIDSince(
+	ctx context.Context,
+	slotID int,
+	since time.Time,
+) ([]*repository.Counters, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	period := since.Truncate(repository.CountersPeriod)
+	countersList := make([]*repository.Counters, 0)
+
+	for _, counters := range c.DB {
+		if counters.SlotID == slotID && !counters.Period.Before(period) {
+			counters := counters
+			countersList = append(countersList, &counters)
+		}
+	}
+
+	return countersList, nil
+}
+
+// Removes the counters of the periods before the time, the totals are kept
+func (c *CountersRepository) RemoveBefore(ctx context.Context, before time.Time) error {
+	c.Lock()
+	defer c.Unlock()
+
+	period := before.Truncate(repository.CountersPeriod)
+
+	for key := range c.DB {
+		if key.period.Before(period) {
+			delete(c.DB, key)
+		}
+	}
+
+	return nil
+}
+
+// Adds the delta to the stored counters of its key
+func merge(db map[countersKey]repository.Counters, delta repository.Counters) {
+	key := countersKey{delta.SlotID, delta.GroupID, delta.BannerID, delta.Period, delta.Position}
+
+	stored, has := db[key]
+	if !has {
+		stored = repository.Counters{
+			SlotID:   delta.SlotID,
+			GroupID:  delta.GroupID,
+			BannerID: delta.BannerID,
+			Period:   delta.Period,
+			Position: delta.Position,
+		}
+	}
+
+	stored.Merge(delta)
+	db[key] = stored
+}
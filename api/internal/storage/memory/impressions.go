@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+// Memory impressions repository
+type ImpressionsRepository struct {
+	sync.RWMutex
+	DB map[string][]repository.Impression
+}
+
+// Will return new memory impressions repository
+func NewImpressionsRepository() *ImpressionsRepository {
+	return &ImpressionsRepository{
+		DB: make(map[string][]repository.Impression),
+	}
+}
+
+// Adds the impressions of the banners to the visitors, the expired impressions of the visitors are removed
+func (i *ImpressionsRepository) Add(ctx context.Context, impressions ...repository.Impression) error {
+	i.Lock()
+	defer i.Unlock()
+
+	for _, impression := range impressions {
+		kept := i.DB[impression.VisitorID][:0]
+		for _, stored := range i.DB[impression.VisitorID] {
+			if !stored.IsExpired(impression.CreatedAt) {
+				kept = append(kept, stored)
+			}
+		}
+
+		i.DB[impression.VisitorID] = append(kept, impression)
+	}
+
+	return nil
+}
+
+// Find all the impressions of the visitor not expired by the time
+func (i *ImpressionsRepository) FindAllByVisitorID(
+	ctx context.Context,
+	visitorID string,
+	now time.Time,
+) ([]*repository.Impression, error) {
+	i.RLock()
+	defer i.RUnlock()
+
+	impressions := make([]*repository.Impression, 0)
+
+	for _, impression := range i.DB[visitorID] {
+		if !impression.IsExpired(now) {
+			impression := impression
+			impressions = append(impressions, &impression)
+		}
+	}
+
+	return impressions, nil
+}
+
+// Removes all the impressions expired by the time
+func (i *ImpressionsRepository) RemoveExpired(ctx context.Context, now time.Time) error {
+	i.Lock()
+	defer i.Unlock()
+
+	for visitorID, impressions := range i.DB {
+		kept := impressions[:0]
+
+		for _, impression := range impressions {
+			if !impression.IsExpired(now) {
+				kept = append(kept, impression)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(i.DB, visitorID)
+		} else {
+			i.DB[visitorID] = kept
+		}
+	}
+
+	return nil
+}
@@ -79,6 +79,25 @@ func (r *RotationRepository) FindAllBySlotID(ctx context.Context, slotID int) ([
 	return rotations, nil
 }
 
+// Updates the settings of the rotation of the banner
+func (r *RotationRepository) Update(ctx context.Context, rotation repository.Rotation) (*repository.Rotation, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, stored := range r.DB {
+		if stored.BannerID == rotation.BannerID {
+			rotation.ID = stored.ID
+			rotation.SlotID = stored.SlotID
+			rotation.CreatedAt = stored.CreatedAt
+			r.DB[stored.ID] = rotation
+
+			return &rotation, nil
+		}
+	}
+
+	return nil, ErrRotationNotFound
+}
+
 // Removes the banner from the rotation
 func (r *RotationRepository) Remove(ctx context.Context, bannerID int) error {
 	r.Lock()
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrSlotNotFound = errors.New("slot not found")
+)
+
+// Memory slot repository
+type SlotRepository struct {
+	sync.RWMutex
+	DB map[int]repository.Slot
+}
+
+// Will return new memory slot repository
+func NewSlotRepository() *SlotRepository {
+	return &SlotRepository{
+		DB: make(map[int]repository.Slot),
+	}
+}
+
+// Saves the slot settings, the slot without the enabled flag keeps the stored one
+func (s *SlotRepository) Save(ctx context.Context, slot repository.Slot) (*repository.Slot, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if stored, has := s.DB[slot.ID]; has && slot.Enabled == nil {
+		slot.Enabled = stored.Enabled
+	}
+
+	s.DB[slot.ID] = slot
+
+	return &slot, nil
+}
+
+// Find one slot by id, returns nil if the slot has no settings
+func (s *SlotRepository) FindOneByID(ctx context.Context, slotID int) (*repository.Slot, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	slot, has := s.DB[slotID]
+	if !has {
+		return nil, nil
+	}
+
+	return &slot, nil
+}
+
+// Find all the slots with settings ordered by id
+func (s *SlotRepository) FindAll(ctx context.Context) ([]*repository.Slot, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	slots := make([]*repository.Slot, 0, len(s.DB))
+
+	for _, slot := range s.DB {
+		slot := slot
+		slots = append(slots, &slot)
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].ID < slots[j].ID
+	})
+
+	return slots, nil
+}
+
+// Removes the settings of the slot
+func (s *SlotRepository) Remove(ctx context.Context, slotID int) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, has := s.DB[slotID]; !has {
+		return ErrSlotNotFound
+	}
+
+	delete(s.DB, slotID)
+
+	return nil
+}
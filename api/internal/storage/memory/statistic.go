@@ -41,6 +41,20 @@ func (s *StatisticsRepository) Add(
 	return &statistics, nil
 }
 
+// Adds the statistics in one batch
+func (s *StatisticsRepository) AddBatch(ctx context.Context, statisticsList ...repository.Statistics) error {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, statistics := range statisticsList {
+		statistics.ID = s.ID
+		s.DB[statistics.ID] = statistics
+		s.ID++
+	}
+
+	return nil
+}
+
 // Find all the statistics by slot and group
 func (s *StatisticsRepository) FindAllBySlotIDAndGroupID(
 	ctx context.Context,
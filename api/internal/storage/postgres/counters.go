@@ -0,0 +1,321 @@
+package postgres
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"sort"
+	"time"
+)
+
+const (
+	queryAddCounters = `INSERT INTO counters(slot_id, group_id, banner_id, period, position, views, clicks, conversions, value,
+		inverse_probability) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (slot_id, group_id, banner_id, period, position) DO UPDATE SET
+		views=counters.views+EXCLUDED.views, clicks=counters.clicks+EXCLUDED.clicks,
+		conversions=counters.conversions+EXCLUDED.conversions, value=counters.value+EXCLUDED.value,
+		inverse_probability=counters.inverse_probability+EXCLUDED.inverse_probability`
+	queryAddCounterTotals = `INSERT INTO counter_totals(slot_id, group_id, banner_id, position, views, clicks, conversions,
+		value, inverse_probability) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (slot_id, group_id, banner_id, position) DO UPDATE SET
+		views=counter_totals.views+EXCLUDED.views, clicks=counter_totals.clicks+EXCLUDED.clicks,
+		conversions=counter_totals.conversions+EXCLUDED.conversions, value=counter_totals.value+EXCLUDED.value,
+		inverse_probability=counter_totals.inverse_probability+EXCLUDED.inverse_probability`
+	queryFindCountersBySlotIDAndGroupID        = `SELECT * FROM counters WHERE slot_id=$1 AND group_id=$2`
+	queryFindCounterTotalsBySlotIDAndGroupID   = `SELECT * FROM counter_totals WHERE slot_id=$1 AND group_id=$2`
+	queryFindCounterTotalsBySlotID             = `SELECT * FROM counter_totals WHERE slot_id=$1`
+	queryFindCounterTotalsByBannerIDAndGroupID = `SELECT * FROM counter_totals WHERE banner_id=$1 AND group_id=$2`
+	queryFindCountersBySlotIDSince             = `SELECT * FROM counters WHERE slot_id=$1 AND period>=$2`
+	queryRemoveCountersBefore                  = `DELETE FROM counters WHERE period<$1`
+)
+
+// Postgres counters repository
+type CountersRepository struct {
+	DB     *sqlx.DB
+	logger zap.Logger
+}
+
+// Returns the postgres counters repository
+func NewCountersRepository(db *sqlx.DB, logger zap.Logger) *CountersRepository {
+	return &CountersRepository{
+		DB:     db,
+		logger: logger,
+	}
+}
+
+// Adds the counters to the stored counters of the same slot, group, banner, period and position
+// and to the totals of the same slot, group, banner and position in one transaction,
+// joins the transaction of the context if there is one
+func (c *CountersRepository) Add(ctx context.Context, counters ...repository.Counters) error {
+	if ctx.Err() == context.Canceled {
+		c.logger.Info(
+			"Adding counters was canceled due to context cancellation",
+			zap.Int("count", len(counters)),
+		)
+
+		return errors.New("adding counters was canceled due to context cancellation")
+	}
+
+	if tx, has := transaction(ctx); has {
+		return c.add(ctx, tx, counters)
+	}
+
+	tx, err := c.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "error when starting the transaction of counters")
+	}
+
+	err = c.add(ctx, tx, counters)
+	if err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "error when committing the counters")
+	}
+
+	return nil
+}
+
+// Adds the counters and their totals in the transaction, the rows are updated in the order of their keys,
+// so the concurrent transactions do not deadlock
+func (c *CountersRepository) add(ctx context.Context, tx *sqlx.Tx, counters []repository.Counters) error {
+	totals := make([]repository.Counters, 0, len(counters))
+	for _, delta := range counters {
+		totals = append(totals, delta.Total())
+	}
+
+	hourly := aggregate(counters)
+	totals = aggregate(totals)
+
+	for _, delta := range hourly {
+		_, err := tx.ExecContext(
+			ctx,
+			queryAddCounters,
+			delta.SlotID,
+			delta.GroupID,
+			delta.BannerID,
+			delta.Period,
+			delta.Position,
+			delta.Views,
+			delta.Clicks,
+			delta.Conversions,
+			delta.Value,
+			delta.InverseProbability,
+		)
+		if err != nil {
+			return errors.Wrap(err, "error when adding counters")
+		}
+	}
+
+	for _, delta := range totals {
+		_, err := tx.ExecContext(
+			ctx,
+			queryAddCounterTotals,
+			delta.SlotID,
+			delta.GroupID,
+			delta.BannerID,
+			delta.Position,
+			delta.Views,
+			delta.Clicks,
+			delta.Conversions,
+			delta.Value,
+			delta.InverseProbability,
+		)
+		if err != nil {
+			return errors.Wrap(err, "error when adding counter totals")
+		}
+	}
+
+	return nil
+}
+
+// Find all the counters by slot and group
+func (c *CountersRepository) FindAllBySlotIDAndGroupID(
+	ctx context.Context,
+	slotID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	if ctx.Err() == context.Canceled {
+		c.logger.Info(
+			"Search for all counters was interrupted due to context cancellation",
+			zap.Int("slotID", slotID),
+			zap.Int("groupID", groupID),
+		)
+
+		return nil, errors.New("search for all counters was interrupted due to context cancellation")
+	}
+
+	return c.find(ctx, queryFindCountersBySlotIDAndGroupID, slotID, groupID)
+}
+
+// Find the totals of the counters by slot and group, the totals have no period
+func (c *CountersRepository) FindTotalsBySlotIDAndGroupID(
+	ctx context.Context,
+	slotID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	if ctx.Err() == context.Canceled {
+		c.logger.Info(
+			"Search for the counter totals was interrupted due to context cancellation",
+			zap.Int("slotID", slotID),
+			zap.Int("groupID", groupID),
+		)
+
+		return nil, errors.New("search for the counter totals was interrupted due to context cancellation")
+	}
+
+	return c.find(ctx, queryFindCounterTotalsBySlotIDAndGroupID, slotID, groupID)
+}
+
+// Find the totals of the counters of the slot in all the groups
+func (c *CountersRepository) FindTotalsBySlotID(ctx context.Context, slotID int) ([]*repository.Counters, error) {
+	if ctx.Err() == context.Canceled {
+		c.logger.Info(
+			"Search for the counter totals of the slot was interrupted due to context cancellation",
+			zap.Int("slotID", slotID),
+		)
+
+		return nil, errors.New("search for the counter totals of the slot was interrupted due to context cancellation")
+	}
+
+	return c.find(ctx, queryFindCounterTotalsBySlotID, slotID)
+}
+
+// Find the totals of the counters of the banner in all the slots by group
+func (c *CountersRepository) FindTotalsByBannerIDAndGroupID(
+	ctx context.Context,
+	bannerID int,
+	groupID int,
+) ([]*repository.Counters, error) {
+	if ctx.Err() == context.Canceled {
+		c.logger.Info(
+			"Search for the counter totals of the banner was interrupted due to context cancellation",
+			zap.Int("bannerID", bannerID),
+			zap.Int("groupID", groupID),
+		)
+
+		return nil, errors.New("search for the counter totals of the banner was interrupted due to context cancellation")
+	}
+
+	return c.find(ctx, queryFindCounterTotalsByBannerIDAndGroupID, bannerID, groupID)
+}
+
+// Find all the counters of the slot in all the groups from the period of the time
+func (c *CountersRepository) FindAllBySlotIDSince(
+	ctx context.Context,
+	slotID int,
+	since time.Time,
+) ([]*repository.Counters, error) {
+	if ctx.Err() == context.Canceled {
+		c.logger.Info(
+			"Search for all counters of the slot was interrupted due to context cancellation",
+			zap.Int("slotID", slotID),
+			zap.Time("since", since),
+		)
+
+		return nil, errors.New("search for all counters of the slot was interrupted due to context cancellation")
+	}
+
+	return c.find(ctx, queryFindCountersBySlotIDSince, slotID, since.Truncate(repository.CountersPeriod))
+}
+
+// Removes the counters of the periods before the time, the totals are kept
+func (c *CountersRepository) RemoveBefore(ctx context.Context, before time.Time) error {
+	if ctx.Err() == context.Canceled {
+		c.logger.Info(
+			"Removing counters was canceled due to context cancellation",
+			zap.Time("before", before),
+		)
+
+		return errors.New("removing counters was canceled due to context cancellation")
+	}
+
+	_, err := c.DB.ExecContext(ctx, queryRemoveCountersBefore, before.Truncate(repository.CountersPeriod))
+	if err != nil {
+		return errors.Wrap(err, "error when removing counters")
+	}
+
+	return nil
+}
+
+// Returns the counters found by the query
+func (c *CountersRepository) find(ctx context.Context, query string, args ...interface{}) ([]*repository.Counters, error) {
+	rows, err := c.DB.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching counters")
+	}
+	defer rows.Close()
+
+	countersList := make([]*repository.Counters, 0)
+
+	for rows.Next() {
+		var counters repository.Counters
+		err := rows.StructScan(&counters)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while scanning results to structure")
+		}
+
+		countersList = append(countersList, &counters)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when reading counters")
+	}
+
+	return countersList, nil
+}
+
+// Key of the counters
+type countersKey struct {
+	slotID   int
+	groupID  int
+	bannerID int
+	period   time.Time
+	position int
+}
+
+// Returns the sums of the counters of the same key ordered by the keys
+func aggregate(counters []repository.Counters) []repository.Counters {
+	indexes := make(map[countersKey]int, len(counters))
+	aggregated := make([]repository.Counters, 0, len(counters))
+
+	for _, delta := range counters {
+		key := countersKey{delta.SlotID, delta.GroupID, delta.BannerID, delta.Period, delta.Position}
+
+		i, has := indexes[key]
+		if !has {
+			indexes[key] = len(aggregated)
+			aggregated = append(aggregated, delta)
+
+			continue
+		}
+
+		aggregated[i].Merge(delta)
+	}
+
+	sort.Slice(aggregated, func(i, j int) bool {
+		a, b := aggregated[i], aggregated[j]
+
+		switch {
+		case a.SlotID != b.SlotID:
+			return a.SlotID < b.SlotID
+		case a.GroupID != b.GroupID:
+			return a.GroupID < b.GroupID
+		case a.BannerID != b.BannerID:
+			return a.BannerID < b.BannerID
+		case !a.Period.Equal(b.Period):
+			return a.Period.Before(b.Period)
+		}
+
+		return a.Position < b.Position
+	})
+
+	return aggregated
+}
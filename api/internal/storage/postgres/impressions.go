@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	queryAddImpression = `INSERT INTO impressions(visitor_id, banner_id, slot_id, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`
+	queryFindImpressionsByVisitorID = `SELECT visitor_id, banner_id, slot_id, created_at, expires_at
+		FROM impressions WHERE visitor_id=$1 AND expires_at>$2`
+	queryRemoveExpiredImpressions        = `DELETE FROM impressions WHERE expires_at<=$1`
+	queryRemoveExpiredVisitorImpressions = `DELETE FROM impressions WHERE visitor_id=$1 AND expires_at<=$2`
+)
+
+// Postgres impressions repository
+type ImpressionsRepository struct {
+	DB     *sqlx.DB
+	logger zap.Logger
+}
+
+// Returns the postgres impressions repository
+func NewImpressionsRepository(db *sqlx.DB, logger zap.Logger) *ImpressionsRepository {
+	return &ImpressionsRepository{
+		DB:     db,
+		logger: logger,
+	}
+}
+
+// Adds the impressions of the banners to the visitors in one transaction,
+// the expired impressions of the visitors are removed in it so they do not pile up between the removals
+func (i *ImpressionsRepository) Add(ctx context.Context, impressions ...repository.Impression) error {
+	if ctx.Err() == context.Canceled {
+		i.logger.Info(
+			"Adding impressions was canceled due to context cancellation",
+			zap.Int("count", len(impressions)),
+		)
+
+		return errors.New("adding impressions was canceled due to context cancellation")
+	}
+
+	tx, err := i.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "error when starting the transaction of impressions")
+	}
+
+	removed := make(map[string]bool)
+
+	for _, impression := range impressions {
+		if !removed[impression.VisitorID] {
+			removed[impression.VisitorID] = true
+
+			_, err = tx.ExecContext(ctx, queryRemoveExpiredVisitorImpressions, impression.VisitorID, impression.CreatedAt)
+			if err != nil {
+				tx.Rollback()
+
+				return errors.Wrap(err, "error when removing expired impressions of the visitor")
+			}
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			queryAddImpression,
+			impression.VisitorID,
+			impression.BannerID,
+			impression.SlotID,
+			impression.CreatedAt,
+			impression.ExpiresAt,
+		)
+		if err != nil {
+			tx.Rollback()
+
+			return errors.Wrap(err, "error when adding impressions")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "error when committing the impressions")
+	}
+
+	return nil
+}
+
+// Find all the impressions of the visitor not expired by the time
+func (i *ImpressionsRepository) FindAllByVisitorID(
+	ctx context.Context,
+	visitorID string,
+	now time.Time,
+) ([]*repository.Impression, error) {
+	if ctx.Err() == context.Canceled {
+		i.logger.Info(
+			"Search for impressions was interrupted due to context cancellation",
+			zap.String("visitorID", visitorID),
+		)
+
+		return nil, errors.New("search for impressions was interrupted due to context cancellation")
+	}
+
+	rows, err := i.DB.QueryxContext(ctx, queryFindImpressionsByVisitorID, visitorID, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for impressions by visitorId")
+	}
+	defer rows.Close()
+
+	impressions := make([]*repository.Impression, 0)
+
+	for rows.Next() {
+		var impression repository.Impression
+		err := rows.StructScan(&impression)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while scanning results to structure")
+		}
+
+		impressions = append(impressions, &impression)
+	}
+
+	return impressions, nil
+}
+
+// Removes all the impressions expired by the time
+func (i *ImpressionsRepository) RemoveExpired(ctx context.Context, now time.Time) error {
+	if ctx.Err() == context.Canceled {
+		i.logger.Info("Removal of expired impressions was interrupted due to the cancellation context")
+
+		return errors.New("removal of expired impressions was interrupted due to the cancellation context")
+	}
+
+	_, err := i.DB.ExecContext(ctx, queryRemoveExpiredImpressions, now)
+	if err != nil {
+		return errors.Wrap(err, "error when removing expired impressions")
+	}
+
+	return nil
+}
@@ -10,8 +10,16 @@ import (
 )
 
 const (
-	queryInsertRotation = `INSERT INTO rotations(banner_id, slot_id, description, created_at)
-		VALUES ($1, $2, $3, $4) RETURNING id`
+	queryInsertRotation = `INSERT INTO rotations(banner_id, slot_id, description, status, prior, prior_views, prior_clicks,
+		starts_at, ends_at, timezone, dayparting, weight, min_share, max_share,
+		views_cap, clicks_cap, daily_views_cap, daily_clicks_cap, pacing, targeting, created_at)
+		VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), 'active'), $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
+		$15, $16, $17, $18, $19, $20, $21)
+		RETURNING id, status`
+	queryUpdateRotation = `UPDATE rotations SET description=$2, status=COALESCE(NULLIF($3, ''), 'active'), prior=$4,
+		prior_views=$5, prior_clicks=$6, starts_at=$7, ends_at=$8, timezone=$9, dayparting=$10,
+		weight=$11, min_share=$12, max_share=$13, views_cap=$14, clicks_cap=$15, daily_views_cap=$16,
+		daily_clicks_cap=$17, pacing=$18, targeting=$19 WHERE banner_id=$1 RETURNING *`
 	queryFindRotationByBannerID = `SELECT * FROM rotations WHERE banner_id=$1`
 	queryFindAllBySlotID        = `SELECT * FROM rotations WHERE slot_id=$1`
 	queryRemoveByBannerID       = `DELETE FROM rotations WHERE banner_id=$1`
@@ -48,8 +56,25 @@ func (r *RotationRepository) Add(ctx context.Context, rotation repository.Rotati
 		rotation.BannerID,
 		rotation.SlotID,
 		rotation.Description,
+		rotation.Status,
+		rotation.Prior,
+		rotation.PriorViews,
+		rotation.PriorClicks,
+		rotation.StartsAt,
+		rotation.EndsAt,
+		rotation.Timezone,
+		rotation.Dayparting,
+		rotation.Weight,
+		rotation.MinShare,
+		rotation.MaxShare,
+		rotation.ViewsCap,
+		rotation.ClicksCap,
+		rotation.DailyViewsCap,
+		rotation.DailyClicksCap,
+		rotation.Pacing,
+		rotation.Targeting,
 		rotation.CreatedAt,
-	).Scan(&rotation.ID)
+	).Scan(&rotation.ID, &rotation.Status)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when adding banner in the rotation")
 	}
@@ -68,16 +93,8 @@ func (r *RotationRepository) FindOneByBannerID(ctx context.Context, bannerID int
 		return nil, errors.New("find one rotation was interrupted due to context cancellation")
 	}
 
-	row := r.DB.QueryRowContext(ctx, queryFindRotationByBannerID, bannerID)
-
 	rotation := new(repository.Rotation)
-	err := row.Scan(
-		&rotation.ID,
-		&rotation.BannerID,
-		&rotation.SlotID,
-		&rotation.Description,
-		&rotation.CreatedAt,
-	)
+	err := r.DB.QueryRowxContext(ctx, queryFindRotationByBannerID, bannerID).StructScan(rotation)
 
 	if err == sql.ErrNoRows {
 		r.logger.Warn(
@@ -130,6 +147,50 @@ func (r *RotationRepository) FindAllBySlotID(ctx context.Context, slotID int) ([
 	return rotations, nil
 }
 
+// Updates the settings of the rotation of the banner
+func (r *RotationRepository) Update(ctx context.Context, rotation repository.Rotation) (*repository.Rotation, error) {
+	if ctx.Err() == context.Canceled {
+		r.logger.Info(
+			"Updating the rotation was canceled due to context cancellation",
+			zap.Int("bannerID", rotation.BannerID),
+		)
+
+		return nil, errors.New("updating the rotation was canceled due to context cancellation")
+	}
+
+	updated := new(repository.Rotation)
+	err := r.DB.QueryRowxContext(
+		ctx,
+		queryUpdateRotation,
+		rotation.BannerID,
+		rotation.Description,
+		rotation.Status,
+		rotation.Prior,
+		rotation.PriorViews,
+		rotation.PriorClicks,
+		rotation.StartsAt,
+		rotation.EndsAt,
+		rotation.Timezone,
+		rotation.Dayparting,
+		rotation.Weight,
+		rotation.MinShare,
+		rotation.MaxShare,
+		rotation.ViewsCap,
+		rotation.ClicksCap,
+		rotation.DailyViewsCap,
+		rotation.DailyClicksCap,
+		rotation.Pacing,
+		rotation.Targeting,
+	).StructScan(updated)
+	if err == sql.ErrNoRows {
+		return nil, errors.Wrap(err, "could not find rotation by bannerID")
+	} else if err != nil {
+		return nil, errors.Wrap(err, "error when updating the rotation")
+	}
+
+	return updated, nil
+}
+
 // Removes the banner from the rotation
 func (r *RotationRepository) Remove(ctx context.Context, bannerID int) error {
 	if ctx.Err() == context.Canceled {
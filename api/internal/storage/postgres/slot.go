@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/koind/banner-rotation/api/internal/domain/repository"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	querySaveSlot = `INSERT INTO slots(id, name, description, width, height, strategy, parameters, reward,
+		fallback_banner_id, enabled) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10, true))
+		ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, description=EXCLUDED.description,
+		width=EXCLUDED.width, height=EXCLUDED.height, strategy=EXCLUDED.strategy, parameters=EXCLUDED.parameters,
+		reward=EXCLUDED.reward, fallback_banner_id=EXCLUDED.fallback_banner_id,
+		enabled=COALESCE($10, slots.enabled) RETURNING enabled`
+	queryFindSlotByID = `SELECT * FROM slots WHERE id=$1`
+	queryFindAllSlots = `SELECT * FROM slots ORDER BY id`
+	queryRemoveSlot   = `DELETE FROM slots WHERE id=$1`
+)
+
+// Postgres slot repository
+type SlotRepository struct {
+	DB     *sqlx.DB
+	logger zap.Logger
+}
+
+// Returns the postgres slot repository
+func NewSlotRepository(db *sqlx.DB, logger zap.Logger) *SlotRepository {
+	return &SlotRepository{
+		DB:     db,
+		logger: logger,
+	}
+}
+
+// Saves the slot settings, the slot without the enabled flag keeps the stored one
+func (s *SlotRepository) Save(ctx context.Context, slot repository.Slot) (*repository.Slot, error) {
+	if ctx.Err() == context.Canceled {
+		s.logger.Info(
+			"Saving a slot was canceled due to context cancellation",
+			zap.Int("slotID", slot.ID),
+		)
+
+		return nil, errors.New("saving a slot was canceled due to context cancellation")
+	}
+
+	var enabled bool
+
+	err := s.DB.QueryRowContext(
+		ctx,
+		querySaveSlot,
+		slot.ID,
+		slot.Name,
+		slot.Description,
+		slot.Width,
+		slot.Height,
+		slot.Strategy,
+		slot.Parameters,
+		slot.Reward,
+		slot.FallbackBannerID,
+		slot.Enabled,
+	).Scan(&enabled)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when saving slot")
+	}
+
+	slot.Enabled = &enabled
+
+	return &slot, nil
+}
+
+// Find one slot by id, returns nil if the slot has no settings
+func (s *SlotRepository) FindOneByID(ctx context.Context, slotID int) (*repository.Slot, error) {
+	if ctx.Err() == context.Canceled {
+		s.logger.Info(
+			"Find one slot was interrupted due to context cancellation",
+			zap.Int("slotID", slotID),
+		)
+
+		return nil, errors.New("find one slot was interrupted due to context cancellation")
+	}
+
+	slot := new(repository.Slot)
+	err := s.DB.QueryRowxContext(ctx, queryFindSlotByID, slotID).StructScan(slot)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		s.logger.Warn(
+			"Error when searching for slot by id",
+			zap.Error(err),
+			zap.Int("slotID", slotID),
+		)
+
+		return nil, errors.Wrap(err, "error when searching for slot by id")
+	}
+
+	return slot, nil
+}
+
+// Find all the slots with settings ordered by id
+func (s *SlotRepository) FindAll(ctx context.Context) ([]*repository.Slot, error) {
+	if ctx.Err() == context.Canceled {
+		s.logger.Info("Search for all slots was interrupted due to context cancellation")
+
+		return nil, errors.New("search for all slots was interrupted due to context cancellation")
+	}
+
+	rows, err := s.DB.QueryxContext(ctx, queryFindAllSlots)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when searching for slots")
+	}
+	defer rows.Close()
+
+	slots := make([]*repository.Slot, 0)
+
+	for rows.Next() {
+		var slot repository.Slot
+		err := rows.StructScan(&slot)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while scanning results to structure")
+		}
+
+		slots = append(slots, &slot)
+	}
+
+	return slots, nil
+}
+
+// Removes the settings of the slot
+func (s *SlotRepository) Remove(ctx context.Context, slotID int) error {
+	if ctx.Err() == context.Canceled {
+		s.logger.Info(
+			"Removal of a slot was interrupted due to the cancellation context",
+			zap.Int("slotID", slotID),
+		)
+
+		return errors.New("removal of a slot was interrupted due to the cancellation context")
+	}
+
+	result, err := s.DB.ExecContext(ctx, queryRemoveSlot, slotID)
+	if err != nil {
+		return errors.Wrap(err, "error when remove slot")
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "error when remove slot")
+	}
+
+	if removed == 0 {
+		return errors.Wrap(sql.ErrNoRows, "could not find slot by id")
+	}
+
+	return nil
+}
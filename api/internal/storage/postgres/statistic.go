@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/koind/banner-rotation/api/internal/domain/repository"
 	"github.com/pkg/errors"
@@ -9,12 +10,17 @@ import (
 )
 
 const (
-	queryInsertStatistic = `INSERT INTO statistics(type, banner_id, slot_id, group_id, created_at)
-		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	queryInsertStatistic = `INSERT INTO statistics(type, banner_id, slot_id, group_id, value, position, strategy, attributes, created_at,
+		probability) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+	queryInsertStatistics = `INSERT INTO statistics(type, banner_id, slot_id, group_id, value, position, strategy, attributes, created_at,
+		probability) VALUES `
 	queryFindAllBySlotIDAndGroupID = `SELECT * FROM statistics WHERE slot_id=$1 AND group_id=$2`
 	queryRemoveByStatisticID       = `DELETE FROM statistics WHERE id=$1`
 )
 
+// Number of the statistics inserted by one statement, keeps the number of the parameters under the limit of postgres
+const statisticsBatchSize = 1000
+
 // Postgres statistics repository
 type StatisticsRepository struct {
 	DB     *sqlx.DB
@@ -29,7 +35,7 @@ func NewStatisticsRepository(db *sqlx.DB, logger zap.Logger) *StatisticsReposito
 	}
 }
 
-// Adds statistics
+// Adds statistics, joins the transaction of the context if there is one
 func (s *StatisticsRepository) Add(ctx context.Context, statistics repository.Statistics) (*repository.Statistics, error) {
 	if ctx.Err() == context.Canceled {
 		s.logger.Info(
@@ -40,14 +46,19 @@ func (s *StatisticsRepository) Add(ctx context.Context, statistics repository.St
 		return nil, errors.New("adding a statistics was canceled due to context cancellation")
 	}
 
-	err := s.DB.QueryRowContext(
+	err := executor(ctx, s.DB).QueryRowxContext(
 		ctx,
 		queryInsertStatistic,
 		statistics.Type,
 		statistics.BannerID,
 		statistics.SlotID,
 		statistics.GroupID,
+		statistics.Value,
+		statistics.Position,
+		statistics.Strategy,
+		statistics.Attributes,
 		statistics.CreatedAt,
+		statistics.Probability,
 	).Scan(&statistics.ID)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when adding statistics")
@@ -56,6 +67,60 @@ func (s *StatisticsRepository) Add(ctx context.Context, statistics repository.St
 	return &statistics, nil
 }
 
+// Adds the statistics in one batch with the multi-row inserts, joins the transaction of the context if there is one
+func (s *StatisticsRepository) AddBatch(ctx context.Context, statisticsList ...repository.Statistics) error {
+	if ctx.Err() == context.Canceled {
+		s.logger.Info(
+			"Adding the statistics was canceled due to context cancellation",
+			zap.Int("count", len(statisticsList)),
+		)
+
+		return errors.New("adding the statistics was canceled due to context cancellation")
+	}
+
+	for start := 0; start < len(statisticsList); start += statisticsBatchSize {
+		end := start + statisticsBatchSize
+		if end > len(statisticsList) {
+			end = len(statisticsList)
+		}
+
+		query := []byte(queryInsertStatistics)
+		args := make([]interface{}, 0, (end-start)*10)
+
+		for i, statistics := range statisticsList[start:end] {
+			if i > 0 {
+				query = append(query, ", "...)
+			}
+
+			n := len(args)
+			query = append(query, fmt.Sprintf(
+				"($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+				n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10,
+			)...)
+			args = append(
+				args,
+				statistics.Type,
+				statistics.BannerID,
+				statistics.SlotID,
+				statistics.GroupID,
+				statistics.Value,
+				statistics.Position,
+				statistics.Strategy,
+				statistics.Attributes,
+				statistics.CreatedAt,
+				statistics.Probability,
+			)
+		}
+
+		_, err := executor(ctx, s.DB).ExecContext(ctx, string(query), args...)
+		if err != nil {
+			return errors.Wrap(err, "error when adding the batch of statistics")
+		}
+	}
+
+	return nil
+}
+
 // Find all the statistics by slot and group
 func (s *StatisticsRepository) FindAllBySlotIDAndGroupID(
 	ctx context.Context,
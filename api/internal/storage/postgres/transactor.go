@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Key of the transaction in the context
+type transactionKey struct{}
+
+// Postgres transactor
+type Transactor struct {
+	DB     *sqlx.DB
+	logger zap.Logger
+}
+
+// Returns the postgres transactor
+func NewTransactor(db *sqlx.DB, logger zap.Logger) *Transactor {
+	return &Transactor{
+		DB:     db,
+		logger: logger,
+	}
+}
+
+// Runs the function in one transaction, the function joins the transaction of the context if there is one
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, has := transaction(ctx); has {
+		return fn(ctx)
+	}
+
+	if ctx.Err() == context.Canceled {
+		t.logger.Info("Transaction was canceled due to context cancellation")
+
+		return errors.New("transaction was canceled due to context cancellation")
+	}
+
+	tx, err := t.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "error when starting the transaction")
+	}
+
+	err = fn(context.WithValue(ctx, transactionKey{}, tx))
+	if err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "error when committing the transaction")
+	}
+
+	return nil
+}
+
+// Returns the transaction of the context
+func transaction(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, has := ctx.Value(transactionKey{}).(*sqlx.Tx)
+
+	return tx, has
+}
+
+// Returns the transaction of the context or the database when there is none
+func executor(ctx context.Context, db *sqlx.DB) sqlx.ExtContext {
+	if tx, has := transaction(ctx); has {
+		return tx
+	}
+
+	return db
+}
@@ -7,7 +7,9 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -26,12 +28,28 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type RotationRequest struct {
-	BannerId             int32    `protobuf:"varint,1,opt,name=banner_id,json=bannerId,proto3" json:"banner_id,omitempty"`
-	SlotId               int32    `protobuf:"varint,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
-	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	BannerId             int32                `protobuf:"varint,1,opt,name=banner_id,json=bannerId,proto3" json:"banner_id,omitempty"`
+	SlotId               int32                `protobuf:"varint,2,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	Description          string               `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Prior                string               `protobuf:"bytes,4,opt,name=prior,proto3" json:"prior,omitempty"`
+	PriorViews           float64              `protobuf:"fixed64,5,opt,name=prior_views,json=priorViews,proto3" json:"prior_views,omitempty"`
+	PriorClicks          float64              `protobuf:"fixed64,6,opt,name=prior_clicks,json=priorClicks,proto3" json:"prior_clicks,omitempty"`
+	StartsAt             *timestamp.Timestamp `protobuf:"bytes,7,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt               *timestamp.Timestamp `protobuf:"bytes,8,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Timezone             string               `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Dayparting           []*DaypartRule       `protobuf:"bytes,10,rep,name=dayparting,proto3" json:"dayparting,omitempty"`
+	Weight               float64              `protobuf:"fixed64,11,opt,name=weight,proto3" json:"weight,omitempty"`
+	MinShare             float64              `protobuf:"fixed64,12,opt,name=min_share,json=minShare,proto3" json:"min_share,omitempty"`
+	MaxShare             float64              `protobuf:"fixed64,13,opt,name=max_share,json=maxShare,proto3" json:"max_share,omitempty"`
+	ViewsCap             int32                `protobuf:"varint,14,opt,name=views_cap,json=viewsCap,proto3" json:"views_cap,omitempty"`
+	ClicksCap            int32                `protobuf:"varint,15,opt,name=clicks_cap,json=clicksCap,proto3" json:"clicks_cap,omitempty"`
+	DailyViewsCap        int32                `protobuf:"varint,16,opt,name=daily_views_cap,json=dailyViewsCap,proto3" json:"daily_views_cap,omitempty"`
+	DailyClicksCap       int32                `protobuf:"varint,17,opt,name=daily_clicks_cap,json=dailyClicksCap,proto3" json:"daily_clicks_cap,omitempty"`
+	Pacing               bool                 `protobuf:"varint,18,opt,name=pacing,proto3" json:"pacing,omitempty"`
+	Targeting            *TargetingRules      `protobuf:"bytes,19,opt,name=targeting,proto3" json:"targeting,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *RotationRequest) Reset()         { *m = RotationRequest{} }
@@ -80,12 +98,141 @@ func (m *RotationRequest) GetDescription() string {
 	return ""
 }
 
+func (m *RotationRequest) GetPrior() string {
+	if m != nil {
+		return m.Prior
+	}
+	return ""
+}
+
+func (m *RotationRequest) GetPriorViews() float64 {
+	if m != nil {
+		return m.PriorViews
+	}
+	return 0
+}
+
+func (m *RotationRequest) GetPriorClicks() float64 {
+	if m != nil {
+		return m.PriorClicks
+	}
+	return 0
+}
+
+func (m *RotationRequest) GetStartsAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartsAt
+	}
+	return nil
+}
+
+func (m *RotationRequest) GetEndsAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndsAt
+	}
+	return nil
+}
+
+func (m *RotationRequest) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *RotationRequest) GetDayparting() []*DaypartRule {
+	if m != nil {
+		return m.Dayparting
+	}
+	return nil
+}
+
+func (m *RotationRequest) GetWeight() float64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *RotationRequest) GetMinShare() float64 {
+	if m != nil {
+		return m.MinShare
+	}
+	return 0
+}
+
+func (m *RotationRequest) GetMaxShare() float64 {
+	if m != nil {
+		return m.MaxShare
+	}
+	return 0
+}
+
+func (m *RotationRequest) GetViewsCap() int32 {
+	if m != nil {
+		return m.ViewsCap
+	}
+	return 0
+}
+
+func (m *RotationRequest) GetClicksCap() int32 {
+	if m != nil {
+		return m.ClicksCap
+	}
+	return 0
+}
+
+func (m *RotationRequest) GetDailyViewsCap() int32 {
+	if m != nil {
+		return m.DailyViewsCap
+	}
+	return 0
+}
+
+func (m *RotationRequest) GetDailyClicksCap() int32 {
+	if m != nil {
+		return m.DailyClicksCap
+	}
+	return 0
+}
+
+func (m *RotationRequest) GetPacing() bool {
+	if m != nil {
+		return m.Pacing
+	}
+	return false
+}
+
+func (m *RotationRequest) GetTargeting() *TargetingRules {
+	if m != nil {
+		return m.Targeting
+	}
+	return nil
+}
+
 type RotationResponse struct {
 	Id                   int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	BannerId             int32                `protobuf:"varint,2,opt,name=banner_id,json=bannerId,proto3" json:"banner_id,omitempty"`
 	SlotId               int32                `protobuf:"varint,3,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
 	Description          string               `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
 	CreateAt             *timestamp.Timestamp `protobuf:"bytes,5,opt,name=create_at,json=createAt,proto3" json:"create_at,omitempty"`
+	Prior                string               `protobuf:"bytes,6,opt,name=prior,proto3" json:"prior,omitempty"`
+	PriorViews           float64              `protobuf:"fixed64,7,opt,name=prior_views,json=priorViews,proto3" json:"prior_views,omitempty"`
+	PriorClicks          float64              `protobuf:"fixed64,8,opt,name=prior_clicks,json=priorClicks,proto3" json:"prior_clicks,omitempty"`
+	StartsAt             *timestamp.Timestamp `protobuf:"bytes,9,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt               *timestamp.Timestamp `protobuf:"bytes,10,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Timezone             string               `protobuf:"bytes,11,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Dayparting           []*DaypartRule       `protobuf:"bytes,12,rep,name=dayparting,proto3" json:"dayparting,omitempty"`
+	Status               string               `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
+	Weight               float64              `protobuf:"fixed64,14,opt,name=weight,proto3" json:"weight,omitempty"`
+	MinShare             float64              `protobuf:"fixed64,15,opt,name=min_share,json=minShare,proto3" json:"min_share,omitempty"`
+	MaxShare             float64              `protobuf:"fixed64,16,opt,name=max_share,json=maxShare,proto3" json:"max_share,omitempty"`
+	ViewsCap             int32                `protobuf:"varint,17,opt,name=views_cap,json=viewsCap,proto3" json:"views_cap,omitempty"`
+	ClicksCap            int32                `protobuf:"varint,18,opt,name=clicks_cap,json=clicksCap,proto3" json:"clicks_cap,omitempty"`
+	DailyViewsCap        int32                `protobuf:"varint,19,opt,name=daily_views_cap,json=dailyViewsCap,proto3" json:"daily_views_cap,omitempty"`
+	DailyClicksCap       int32                `protobuf:"varint,20,opt,name=daily_clicks_cap,json=dailyClicksCap,proto3" json:"daily_clicks_cap,omitempty"`
+	Pacing               bool                 `protobuf:"varint,21,opt,name=pacing,proto3" json:"pacing,omitempty"`
+	Targeting            *TargetingRules      `protobuf:"bytes,22,opt,name=targeting,proto3" json:"targeting,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -151,384 +298,2047 @@ func (m *RotationResponse) GetCreateAt() *timestamp.Timestamp {
 	return nil
 }
 
-type Select struct {
-	SlotId               int32    `protobuf:"varint,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
-	GroupId              int32    `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *RotationResponse) GetPrior() string {
+	if m != nil {
+		return m.Prior
+	}
+	return ""
 }
 
-func (m *Select) Reset()         { *m = Select{} }
-func (m *Select) String() string { return proto.CompactTextString(m) }
-func (*Select) ProtoMessage()    {}
-func (*Select) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b40cafcd4234784, []int{2}
+func (m *RotationResponse) GetPriorViews() float64 {
+	if m != nil {
+		return m.PriorViews
+	}
+	return 0
 }
 
-func (m *Select) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Select.Unmarshal(m, b)
+func (m *RotationResponse) GetPriorClicks() float64 {
+	if m != nil {
+		return m.PriorClicks
+	}
+	return 0
 }
-func (m *Select) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Select.Marshal(b, m, deterministic)
+
+func (m *RotationResponse) GetStartsAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartsAt
+	}
+	return nil
 }
-func (m *Select) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Select.Merge(m, src)
+
+func (m *RotationResponse) GetEndsAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndsAt
+	}
+	return nil
 }
-func (m *Select) XXX_Size() int {
-	return xxx_messageInfo_Select.Size(m)
+
+func (m *RotationResponse) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
 }
-func (m *Select) XXX_DiscardUnknown() {
-	xxx_messageInfo_Select.DiscardUnknown(m)
+
+func (m *RotationResponse) GetDayparting() []*DaypartRule {
+	if m != nil {
+		return m.Dayparting
+	}
+	return nil
 }
 
-var xxx_messageInfo_Select proto.InternalMessageInfo
+func (m *RotationResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
 
-func (m *Select) GetSlotId() int32 {
+func (m *RotationResponse) GetWeight() float64 {
 	if m != nil {
-		return m.SlotId
+		return m.Weight
 	}
 	return 0
 }
 
-func (m *Select) GetGroupId() int32 {
+func (m *RotationResponse) GetMinShare() float64 {
 	if m != nil {
-		return m.GroupId
+		return m.MinShare
 	}
 	return 0
 }
 
-type Banner struct {
-	Id                   int32    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *RotationResponse) GetMaxShare() float64 {
+	if m != nil {
+		return m.MaxShare
+	}
+	return 0
 }
 
-func (m *Banner) Reset()         { *m = Banner{} }
-func (m *Banner) String() string { return proto.CompactTextString(m) }
-func (*Banner) ProtoMessage()    {}
-func (*Banner) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b40cafcd4234784, []int{3}
+func (m *RotationResponse) GetViewsCap() int32 {
+	if m != nil {
+		return m.ViewsCap
+	}
+	return 0
 }
 
-func (m *Banner) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Banner.Unmarshal(m, b)
-}
-func (m *Banner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Banner.Marshal(b, m, deterministic)
-}
-func (m *Banner) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Banner.Merge(m, src)
+func (m *RotationResponse) GetClicksCap() int32 {
+	if m != nil {
+		return m.ClicksCap
+	}
+	return 0
 }
-func (m *Banner) XXX_Size() int {
-	return xxx_messageInfo_Banner.Size(m)
+
+func (m *RotationResponse) GetDailyViewsCap() int32 {
+	if m != nil {
+		return m.DailyViewsCap
+	}
+	return 0
 }
-func (m *Banner) XXX_DiscardUnknown() {
-	xxx_messageInfo_Banner.DiscardUnknown(m)
+
+func (m *RotationResponse) GetDailyClicksCap() int32 {
+	if m != nil {
+		return m.DailyClicksCap
+	}
+	return 0
 }
 
-var xxx_messageInfo_Banner proto.InternalMessageInfo
+func (m *RotationResponse) GetPacing() bool {
+	if m != nil {
+		return m.Pacing
+	}
+	return false
+}
 
-func (m *Banner) GetId() int32 {
+func (m *RotationResponse) GetTargeting() *TargetingRules {
 	if m != nil {
-		return m.Id
+		return m.Targeting
 	}
-	return 0
+	return nil
 }
 
-type Transition struct {
+type Share struct {
 	BannerId             int32    `protobuf:"varint,1,opt,name=banner_id,json=bannerId,proto3" json:"banner_id,omitempty"`
-	GroupId              int32    `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Weight               float64  `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	MinShare             float64  `protobuf:"fixed64,3,opt,name=min_share,json=minShare,proto3" json:"min_share,omitempty"`
+	MaxShare             float64  `protobuf:"fixed64,4,opt,name=max_share,json=maxShare,proto3" json:"max_share,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Transition) Reset()         { *m = Transition{} }
-func (m *Transition) String() string { return proto.CompactTextString(m) }
-func (*Transition) ProtoMessage()    {}
-func (*Transition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b40cafcd4234784, []int{4}
+func (m *Share) Reset()         { *m = Share{} }
+func (m *Share) String() string { return proto.CompactTextString(m) }
+func (*Share) ProtoMessage()    {}
+func (*Share) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{2}
 }
 
-func (m *Transition) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Transition.Unmarshal(m, b)
+func (m *Share) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Share.Unmarshal(m, b)
 }
-func (m *Transition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Transition.Marshal(b, m, deterministic)
+func (m *Share) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Share.Marshal(b, m, deterministic)
 }
-func (m *Transition) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Transition.Merge(m, src)
+func (m *Share) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Share.Merge(m, src)
 }
-func (m *Transition) XXX_Size() int {
-	return xxx_messageInfo_Transition.Size(m)
+func (m *Share) XXX_Size() int {
+	return xxx_messageInfo_Share.Size(m)
 }
-func (m *Transition) XXX_DiscardUnknown() {
-	xxx_messageInfo_Transition.DiscardUnknown(m)
+func (m *Share) XXX_DiscardUnknown() {
+	xxx_messageInfo_Share.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Transition proto.InternalMessageInfo
+var xxx_messageInfo_Share proto.InternalMessageInfo
 
-func (m *Transition) GetBannerId() int32 {
+func (m *Share) GetBannerId() int32 {
 	if m != nil {
 		return m.BannerId
 	}
 	return 0
 }
 
-func (m *Transition) GetGroupId() int32 {
+func (m *Share) GetWeight() float64 {
 	if m != nil {
-		return m.GroupId
+		return m.Weight
 	}
 	return 0
 }
 
-type Status struct {
-	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+func (m *Share) GetMinShare() float64 {
+	if m != nil {
+		return m.MinShare
+	}
+	return 0
+}
+
+func (m *Share) GetMaxShare() float64 {
+	if m != nil {
+		return m.MaxShare
+	}
+	return 0
+}
+
+type Budget struct {
+	BannerId             int32    `protobuf:"varint,1,opt,name=banner_id,json=bannerId,proto3" json:"banner_id,omitempty"`
+	ViewsCap             int32    `protobuf:"varint,2,opt,name=views_cap,json=viewsCap,proto3" json:"views_cap,omitempty"`
+	ClicksCap            int32    `protobuf:"varint,3,opt,name=clicks_cap,json=clicksCap,proto3" json:"clicks_cap,omitempty"`
+	DailyViewsCap        int32    `protobuf:"varint,4,opt,name=daily_views_cap,json=dailyViewsCap,proto3" json:"daily_views_cap,omitempty"`
+	DailyClicksCap       int32    `protobuf:"varint,5,opt,name=daily_clicks_cap,json=dailyClicksCap,proto3" json:"daily_clicks_cap,omitempty"`
+	Pacing               bool     `protobuf:"varint,6,opt,name=pacing,proto3" json:"pacing,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Status) Reset()         { *m = Status{} }
-func (m *Status) String() string { return proto.CompactTextString(m) }
-func (*Status) ProtoMessage()    {}
-func (*Status) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b40cafcd4234784, []int{5}
+func (m *Budget) Reset()         { *m = Budget{} }
+func (m *Budget) String() string { return proto.CompactTextString(m) }
+func (*Budget) ProtoMessage()    {}
+func (*Budget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{3}
 }
 
-func (m *Status) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Status.Unmarshal(m, b)
+func (m *Budget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Budget.Unmarshal(m, b)
 }
-func (m *Status) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Status.Marshal(b, m, deterministic)
+func (m *Budget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Budget.Marshal(b, m, deterministic)
 }
-func (m *Status) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Status.Merge(m, src)
+func (m *Budget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Budget.Merge(m, src)
 }
-func (m *Status) XXX_Size() int {
-	return xxx_messageInfo_Status.Size(m)
+func (m *Budget) XXX_Size() int {
+	return xxx_messageInfo_Budget.Size(m)
 }
-func (m *Status) XXX_DiscardUnknown() {
-	xxx_messageInfo_Status.DiscardUnknown(m)
+func (m *Budget) XXX_DiscardUnknown() {
+	xxx_messageInfo_Budget.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Status proto.InternalMessageInfo
+var xxx_messageInfo_Budget proto.InternalMessageInfo
 
-func (m *Status) GetStatus() string {
+func (m *Budget) GetBannerId() int32 {
 	if m != nil {
-		return m.Status
+		return m.BannerId
 	}
-	return ""
+	return 0
 }
 
-func init() {
-	proto.RegisterType((*RotationRequest)(nil), "pb.RotationRequest")
-	proto.RegisterType((*RotationResponse)(nil), "pb.RotationResponse")
-	proto.RegisterType((*Select)(nil), "pb.Select")
-	proto.RegisterType((*Banner)(nil), "pb.Banner")
-	proto.RegisterType((*Transition)(nil), "pb.Transition")
-	proto.RegisterType((*Status)(nil), "pb.Status")
+func (m *Budget) GetViewsCap() int32 {
+	if m != nil {
+		return m.ViewsCap
+	}
+	return 0
 }
 
-func init() { proto.RegisterFile("api/api.proto", fileDescriptor_1b40cafcd4234784) }
-
+func (m *Budget) GetClicksCap() int32 {
+	if m != nil {
+		return m.ClicksCap
+	}
+	return 0
+}
+
+func (m *Budget) GetDailyViewsCap() int32 {
+	if m != nil {
+		return m.DailyViewsCap
+	}
+	return 0
+}
+
+func (m *Budget) GetDailyClicksCap() int32 {
+	if m != nil {
+		return m.DailyClicksCap
+	}
+	return 0
+}
+
+func (m *Budget) GetPacing() bool {
+	if m != nil {
+		return m.Pacing
+	}
+	return false
+}
+
+type TargetingRules struct {
+	Groups               []int32  `protobuf:"varint,1,rep,packed,name=groups,proto3" json:"groups,omitempty"`
+	DeniedGroups         []int32  `protobuf:"varint,2,rep,packed,name=denied_groups,json=deniedGroups,proto3" json:"denied_groups,omitempty"`
+	Countries            []string `protobuf:"bytes,3,rep,name=countries,proto3" json:"countries,omitempty"`
+	DeniedCountries      []string `protobuf:"bytes,4,rep,name=denied_countries,json=deniedCountries,proto3" json:"denied_countries,omitempty"`
+	Devices              []string `protobuf:"bytes,5,rep,name=devices,proto3" json:"devices,omitempty"`
+	DeniedDevices        []string `protobuf:"bytes,6,rep,name=denied_devices,json=deniedDevices,proto3" json:"denied_devices,omitempty"`
+	Languages            []string `protobuf:"bytes,7,rep,name=languages,proto3" json:"languages,omitempty"`
+	DeniedLanguages      []string `protobuf:"bytes,8,rep,name=denied_languages,json=deniedLanguages,proto3" json:"denied_languages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TargetingRules) Reset()         { *m = TargetingRules{} }
+func (m *TargetingRules) String() string { return proto.CompactTextString(m) }
+func (*TargetingRules) ProtoMessage()    {}
+func (*TargetingRules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{4}
+}
+
+func (m *TargetingRules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TargetingRules.Unmarshal(m, b)
+}
+func (m *TargetingRules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TargetingRules.Marshal(b, m, deterministic)
+}
+func (m *TargetingRules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TargetingRules.Merge(m, src)
+}
+func (m *TargetingRules) XXX_Size() int {
+	return xxx_messageInfo_TargetingRules.Size(m)
+}
+func (m *TargetingRules) XXX_DiscardUnknown() {
+	xxx_messageInfo_TargetingRules.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TargetingRules proto.InternalMessageInfo
+
+func (m *TargetingRules) GetGroups() []int32 {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+func (m *TargetingRules) GetDeniedGroups() []int32 {
+	if m != nil {
+		return m.DeniedGroups
+	}
+	return nil
+}
+
+func (m *TargetingRules) GetCountries() []string {
+	if m != nil {
+		return m.Countries
+	}
+	return nil
+}
+
+func (m *TargetingRules) GetDeniedCountries() []string {
+	if m != nil {
+		return m.DeniedCountries
+	}
+	return nil
+}
+
+func (m *TargetingRules) GetDevices() []string {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+func (m *TargetingRules) GetDeniedDevices() []string {
+	if m != nil {
+		return m.DeniedDevices
+	}
+	return nil
+}
+
+func (m *TargetingRules) GetLanguages() []string {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *TargetingRules) GetDeniedLanguages() []string {
+	if m != nil {
+		return m.DeniedLanguages
+	}
+	return nil
+}
+
+type Targeting struct {
+	BannerId             int32           `protobuf:"varint,1,opt,name=banner_id,json=bannerId,proto3" json:"banner_id,omitempty"`
+	Rules                *TargetingRules `protobuf:"bytes,2,opt,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Targeting) Reset()         { *m = Targeting{} }
+func (m *Targeting) String() string { return proto.CompactTextString(m) }
+func (*Targeting) ProtoMessage()    {}
+func (*Targeting) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{5}
+}
+
+func (m *Targeting) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Targeting.Unmarshal(m, b)
+}
+func (m *Targeting) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Targeting.Marshal(b, m, deterministic)
+}
+func (m *Targeting) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Targeting.Merge(m, src)
+}
+func (m *Targeting) XXX_Size() int {
+	return xxx_messageInfo_Targeting.Size(m)
+}
+func (m *Targeting) XXX_DiscardUnknown() {
+	xxx_messageInfo_Targeting.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Targeting proto.InternalMessageInfo
+
+func (m *Targeting) GetBannerId() int32 {
+	if m != nil {
+		return m.BannerId
+	}
+	return 0
+}
+
+func (m *Targeting) GetRules() *TargetingRules {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type DaypartRule struct {
+	Weekdays             []int32  `protobuf:"varint,1,rep,packed,name=weekdays,proto3" json:"weekdays,omitempty"`
+	From                 int32    `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int32    `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DaypartRule) Reset()         { *m = DaypartRule{} }
+func (m *DaypartRule) String() string { return proto.CompactTextString(m) }
+func (*DaypartRule) ProtoMessage()    {}
+func (*DaypartRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{6}
+}
+
+func (m *DaypartRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DaypartRule.Unmarshal(m, b)
+}
+func (m *DaypartRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DaypartRule.Marshal(b, m, deterministic)
+}
+func (m *DaypartRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DaypartRule.Merge(m, src)
+}
+func (m *DaypartRule) XXX_Size() int {
+	return xxx_messageInfo_DaypartRule.Size(m)
+}
+func (m *DaypartRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_DaypartRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DaypartRule proto.InternalMessageInfo
+
+func (m *DaypartRule) GetWeekdays() []int32 {
+	if m != nil {
+		return m.Weekdays
+	}
+	return nil
+}
+
+func (m *DaypartRule) GetFrom() int32 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *DaypartRule) GetTo() int32 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+type Dayparting struct {
+	BannerId             int32          `protobuf:"varint,1,opt,name=banner_id,json=bannerId,proto3" json:"banner_id,omitempty"`
+	Timezone             string         `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Rules                []*DaypartRule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Dayparting) Reset()         { *m = Dayparting{} }
+func (m *Dayparting) String() string { return proto.CompactTextString(m) }
+func (*Dayparting) ProtoMessage()    {}
+func (*Dayparting) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{7}
+}
+
+func (m *Dayparting) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dayparting.Unmarshal(m, b)
+}
+func (m *Dayparting) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dayparting.Marshal(b, m, deterministic)
+}
+func (m *Dayparting) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dayparting.Merge(m, src)
+}
+func (m *Dayparting) XXX_Size() int {
+	return xxx_messageInfo_Dayparting.Size(m)
+}
+func (m *Dayparting) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dayparting.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dayparting proto.InternalMessageInfo
+
+func (m *Dayparting) GetBannerId() int32 {
+	if m != nil {
+		return m.BannerId
+	}
+	return 0
+}
+
+func (m *Dayparting) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *Dayparting) GetRules() []*DaypartRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type Schedule struct {
+	BannerId             int32                `protobuf:"varint,1,opt,name=banner_id,json=bannerId,proto3" json:"banner_id,omitempty"`
+	StartsAt             *timestamp.Timestamp `protobuf:"bytes,2,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt               *timestamp.Timestamp `protobuf:"bytes,3,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Schedule) Reset()         { *m = Schedule{} }
+func (m *Schedule) String() string { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()    {}
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{8}
+}
+
+func (m *Schedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Schedule.Unmarshal(m, b)
+}
+func (m *Schedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Schedule.Marshal(b, m, deterministic)
+}
+func (m *Schedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schedule.Merge(m, src)
+}
+func (m *Schedule) XXX_Size() int {
+	return xxx_messageInfo_Schedule.Size(m)
+}
+func (m *Schedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Schedule proto.InternalMessageInfo
+
+func (m *Schedule) GetBannerId() int32 {
+	if m != nil {
+		return m.BannerId
+	}
+	return 0
+}
+
+func (m *Schedule) GetStartsAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartsAt
+	}
+	return nil
+}
+
+func (m *Schedule) GetEndsAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndsAt
+	}
+	return nil
+}
+
+type Select struct {
+	SlotId               int32             `protobuf:"varint,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	GroupId              int32             `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Count                int32             `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	VisitorId            string            `protobuf:"bytes,5,opt,name=visitor_id,json=visitorId,proto3" json:"visitor_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Select) Reset()         { *m = Select{} }
+func (m *Select) String() string { return proto.CompactTextString(m) }
+func (*Select) ProtoMessage()    {}
+func (*Select) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{9}
+}
+
+func (m *Select) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Select.Unmarshal(m, b)
+}
+func (m *Select) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Select.Marshal(b, m, deterministic)
+}
+func (m *Select) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Select.Merge(m, src)
+}
+func (m *Select) XXX_Size() int {
+	return xxx_messageInfo_Select.Size(m)
+}
+func (m *Select) XXX_DiscardUnknown() {
+	xxx_messageInfo_Select.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Select proto.InternalMessageInfo
+
+func (m *Select) GetSlotId() int32 {
+	if m != nil {
+		return m.SlotId
+	}
+	return 0
+}
+
+func (m *Select) GetGroupId() int32 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *Select) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Select) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *Select) GetVisitorId() string {
+	if m != nil {
+		return m.VisitorId
+	}
+	return ""
+}
+
+type Banner struct {
+	Id                   int32    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Banner) Reset()         { *m = Banner{} }
+func (m *Banner) String() string { return proto.CompactTextString(m) }
+func (*Banner) ProtoMessage()    {}
+func (*Banner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{10}
+}
+
+func (m *Banner) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Banner.Unmarshal(m, b)
+}
+func (m *Banner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Banner.Marshal(b, m, deterministic)
+}
+func (m *Banner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Banner.Merge(m, src)
+}
+func (m *Banner) XXX_Size() int {
+	return xxx_messageInfo_Banner.Size(m)
+}
+func (m *Banner) XXX_DiscardUnknown() {
+	xxx_messageInfo_Banner.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Banner proto.InternalMessageInfo
+
+func (m *Banner) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type Banners struct {
+	Banners              []*Banner `protobuf:"bytes,1,rep,name=banners,proto3" json:"banners,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Banners) Reset()         { *m = Banners{} }
+func (m *Banners) String() string { return proto.CompactTextString(m) }
+func (*Banners) ProtoMessage()    {}
+func (*Banners) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{11}
+}
+
+func (m *Banners) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Banners.Unmarshal(m, b)
+}
+func (m *Banners) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Banners.Marshal(b, m, deterministic)
+}
+func (m *Banners) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Banners.Merge(m, src)
+}
+func (m *Banners) XXX_Size() int {
+	return xxx_messageInfo_Banners.Size(m)
+}
+func (m *Banners) XXX_DiscardUnknown() {
+	xxx_messageInfo_Banners.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Banners proto.InternalMessageInfo
+
+func (m *Banners) GetBanners() []*Banner {
+	if m != nil {
+		return m.Banners
+	}
+	return nil
+}
+
+type Transition struct {
+	BannerId             int32             `protobuf:"varint,1,opt,name=banner_id,json=bannerId,proto3" json:"banner_id,omitempty"`
+	GroupId              int32             `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Transition) Reset()         { *m = Transition{} }
+func (m *Transition) String() string { return proto.CompactTextString(m) }
+func (*Transition) ProtoMessage()    {}
+func (*Transition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{12}
+}
+
+func (m *Transition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Transition.Unmarshal(m, b)
+}
+func (m *Transition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Transition.Marshal(b, m, deterministic)
+}
+func (m *Transition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transition.Merge(m, src)
+}
+func (m *Transition) XXX_Size() int {
+	return xxx_messageInfo_Transition.Size(m)
+}
+func (m *Transition) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transition proto.InternalMessageInfo
+
+func (m *Transition) GetBannerId() int32 {
+	if m != nil {
+		return m.BannerId
+	}
+	return 0
+}
+
+func (m *Transition) GetGroupId() int32 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *Transition) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+type Conversion struct {
+	BannerId             int32             `protobuf:"varint,1,opt,name=banner_id,json=bannerId,proto3" json:"banner_id,omitempty"`
+	GroupId              int32             `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Value                float64           `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Conversion) Reset()         { *m = Conversion{} }
+func (m *Conversion) String() string { return proto.CompactTextString(m) }
+func (*Conversion) ProtoMessage()    {}
+func (*Conversion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{13}
+}
+
+func (m *Conversion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Conversion.Unmarshal(m, b)
+}
+func (m *Conversion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Conversion.Marshal(b, m, deterministic)
+}
+func (m *Conversion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Conversion.Merge(m, src)
+}
+func (m *Conversion) XXX_Size() int {
+	return xxx_messageInfo_Conversion.Size(m)
+}
+func (m *Conversion) XXX_DiscardUnknown() {
+	xxx_messageInfo_Conversion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Conversion proto.InternalMessageInfo
+
+func (m *Conversion) GetBannerId() int32 {
+	if m != nil {
+		return m.BannerId
+	}
+	return 0
+}
+
+func (m *Conversion) GetGroupId() int32 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *Conversion) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Conversion) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+type Status struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Status) Reset()         { *m = Status{} }
+func (m *Status) String() string { return proto.CompactTextString(m) }
+func (*Status) ProtoMessage()    {}
+func (*Status) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{14}
+}
+
+func (m *Status) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Status.Unmarshal(m, b)
+}
+func (m *Status) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Status.Marshal(b, m, deterministic)
+}
+func (m *Status) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Status.Merge(m, src)
+}
+func (m *Status) XXX_Size() int {
+	return xxx_messageInfo_Status.Size(m)
+}
+func (m *Status) XXX_DiscardUnknown() {
+	xxx_messageInfo_Status.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Status proto.InternalMessageInfo
+
+func (m *Status) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type SlotStrategy struct {
+	SlotId               int32              `protobuf:"varint,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	Strategy             string             `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Parameters           map[string]float64 `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SlotStrategy) Reset()         { *m = SlotStrategy{} }
+func (m *SlotStrategy) String() string { return proto.CompactTextString(m) }
+func (*SlotStrategy) ProtoMessage()    {}
+func (*SlotStrategy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{15}
+}
+
+func (m *SlotStrategy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlotStrategy.Unmarshal(m, b)
+}
+func (m *SlotStrategy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlotStrategy.Marshal(b, m, deterministic)
+}
+func (m *SlotStrategy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlotStrategy.Merge(m, src)
+}
+func (m *SlotStrategy) XXX_Size() int {
+	return xxx_messageInfo_SlotStrategy.Size(m)
+}
+func (m *SlotStrategy) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlotStrategy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlotStrategy proto.InternalMessageInfo
+
+func (m *SlotStrategy) GetSlotId() int32 {
+	if m != nil {
+		return m.SlotId
+	}
+	return 0
+}
+
+func (m *SlotStrategy) GetStrategy() string {
+	if m != nil {
+		return m.Strategy
+	}
+	return ""
+}
+
+func (m *SlotStrategy) GetParameters() map[string]float64 {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+type SlotReward struct {
+	SlotId               int32    `protobuf:"varint,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	Reward               string   `protobuf:"bytes,2,opt,name=reward,proto3" json:"reward,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlotReward) Reset()         { *m = SlotReward{} }
+func (m *SlotReward) String() string { return proto.CompactTextString(m) }
+func (*SlotReward) ProtoMessage()    {}
+func (*SlotReward) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{16}
+}
+
+func (m *SlotReward) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlotReward.Unmarshal(m, b)
+}
+func (m *SlotReward) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlotReward.Marshal(b, m, deterministic)
+}
+func (m *SlotReward) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlotReward.Merge(m, src)
+}
+func (m *SlotReward) XXX_Size() int {
+	return xxx_messageInfo_SlotReward.Size(m)
+}
+func (m *SlotReward) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlotReward.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlotReward proto.InternalMessageInfo
+
+func (m *SlotReward) GetSlotId() int32 {
+	if m != nil {
+		return m.SlotId
+	}
+	return 0
+}
+
+func (m *SlotReward) GetReward() string {
+	if m != nil {
+		return m.Reward
+	}
+	return ""
+}
+
+type Slot struct {
+	Id                   int32               `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string              `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string              `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Width                int32               `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32               `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Strategy             string              `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Parameters           map[string]float64  `protobuf:"bytes,7,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	Reward               string              `protobuf:"bytes,8,opt,name=reward,proto3" json:"reward,omitempty"`
+	FallbackBannerId     int32               `protobuf:"varint,9,opt,name=fallback_banner_id,json=fallbackBannerId,proto3" json:"fallback_banner_id,omitempty"`
+	Enabled              *wrappers.BoolValue `protobuf:"bytes,10,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Slot) Reset()         { *m = Slot{} }
+func (m *Slot) String() string { return proto.CompactTextString(m) }
+func (*Slot) ProtoMessage()    {}
+func (*Slot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{17}
+}
+
+func (m *Slot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Slot.Unmarshal(m, b)
+}
+func (m *Slot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Slot.Marshal(b, m, deterministic)
+}
+func (m *Slot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Slot.Merge(m, src)
+}
+func (m *Slot) XXX_Size() int {
+	return xxx_messageInfo_Slot.Size(m)
+}
+func (m *Slot) XXX_DiscardUnknown() {
+	xxx_messageInfo_Slot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Slot proto.InternalMessageInfo
+
+func (m *Slot) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Slot) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Slot) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Slot) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *Slot) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Slot) GetStrategy() string {
+	if m != nil {
+		return m.Strategy
+	}
+	return ""
+}
+
+func (m *Slot) GetParameters() map[string]float64 {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *Slot) GetReward() string {
+	if m != nil {
+		return m.Reward
+	}
+	return ""
+}
+
+func (m *Slot) GetFallbackBannerId() int32 {
+	if m != nil {
+		return m.FallbackBannerId
+	}
+	return 0
+}
+
+func (m *Slot) GetEnabled() *wrappers.BoolValue {
+	if m != nil {
+		return m.Enabled
+	}
+	return nil
+}
+
+type SlotId struct {
+	Id                   int32    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlotId) Reset()         { *m = SlotId{} }
+func (m *SlotId) String() string { return proto.CompactTextString(m) }
+func (*SlotId) ProtoMessage()    {}
+func (*SlotId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{18}
+}
+
+func (m *SlotId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlotId.Unmarshal(m, b)
+}
+func (m *SlotId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlotId.Marshal(b, m, deterministic)
+}
+func (m *SlotId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlotId.Merge(m, src)
+}
+func (m *SlotId) XXX_Size() int {
+	return xxx_messageInfo_SlotId.Size(m)
+}
+func (m *SlotId) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlotId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlotId proto.InternalMessageInfo
+
+func (m *SlotId) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type Slots struct {
+	Slots                []*Slot  `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Slots) Reset()         { *m = Slots{} }
+func (m *Slots) String() string { return proto.CompactTextString(m) }
+func (*Slots) ProtoMessage()    {}
+func (*Slots) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{19}
+}
+
+func (m *Slots) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Slots.Unmarshal(m, b)
+}
+func (m *Slots) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Slots.Marshal(b, m, deterministic)
+}
+func (m *Slots) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Slots.Merge(m, src)
+}
+func (m *Slots) XXX_Size() int {
+	return xxx_messageInfo_Slots.Size(m)
+}
+func (m *Slots) XXX_DiscardUnknown() {
+	xxx_messageInfo_Slots.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Slots proto.InternalMessageInfo
+
+func (m *Slots) GetSlots() []*Slot {
+	if m != nil {
+		return m.Slots
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*RotationRequest)(nil), "pb.RotationRequest")
+	proto.RegisterType((*RotationResponse)(nil), "pb.RotationResponse")
+	proto.RegisterType((*Share)(nil), "pb.Share")
+	proto.RegisterType((*Budget)(nil), "pb.Budget")
+	proto.RegisterType((*TargetingRules)(nil), "pb.TargetingRules")
+	proto.RegisterType((*Targeting)(nil), "pb.Targeting")
+	proto.RegisterType((*DaypartRule)(nil), "pb.DaypartRule")
+	proto.RegisterType((*Dayparting)(nil), "pb.Dayparting")
+	proto.RegisterType((*Schedule)(nil), "pb.Schedule")
+	proto.RegisterType((*Select)(nil), "pb.Select")
+	proto.RegisterMapType((map[string]string)(nil), "pb.Select.AttributesEntry")
+	proto.RegisterType((*Banner)(nil), "pb.Banner")
+	proto.RegisterType((*Banners)(nil), "pb.Banners")
+	proto.RegisterType((*Transition)(nil), "pb.Transition")
+	proto.RegisterMapType((map[string]string)(nil), "pb.Transition.AttributesEntry")
+	proto.RegisterType((*Conversion)(nil), "pb.Conversion")
+	proto.RegisterMapType((map[string]string)(nil), "pb.Conversion.AttributesEntry")
+	proto.RegisterType((*Status)(nil), "pb.Status")
+	proto.RegisterType((*SlotStrategy)(nil), "pb.SlotStrategy")
+	proto.RegisterMapType((map[string]float64)(nil), "pb.SlotStrategy.ParametersEntry")
+	proto.RegisterType((*SlotReward)(nil), "pb.SlotReward")
+	proto.RegisterType((*Slot)(nil), "pb.Slot")
+	proto.RegisterMapType((map[string]float64)(nil), "pb.Slot.ParametersEntry")
+	proto.RegisterType((*SlotId)(nil), "pb.SlotId")
+	proto.RegisterType((*Slots)(nil), "pb.Slots")
+}
+
+func init() { proto.RegisterFile("api/api.proto", fileDescriptor_1b40cafcd4234784) }
+
 var fileDescriptor_1b40cafcd4234784 = []byte{
-	// 370 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x51, 0x4d, 0x6f, 0xe2, 0x30,
-	0x14, 0x54, 0x02, 0x84, 0xe4, 0xf1, 0xb1, 0x2b, 0xef, 0x6a, 0x37, 0x4d, 0x0f, 0x8d, 0x72, 0x40,
-	0x48, 0x95, 0x82, 0x44, 0xa5, 0xf6, 0xd2, 0x0b, 0xa8, 0x17, 0xae, 0x86, 0x53, 0x2f, 0xc8, 0xc1,
-	0x2e, 0xb2, 0x04, 0xb1, 0x1b, 0x3b, 0xfd, 0x63, 0xfd, 0x01, 0xfd, 0x6b, 0x55, 0xec, 0x50, 0x02,
-	0x55, 0xb9, 0x79, 0xe6, 0x4d, 0xde, 0x4c, 0xe6, 0xc1, 0x80, 0x48, 0x3e, 0x21, 0x92, 0xa7, 0xb2,
-	0x10, 0x5a, 0x20, 0x57, 0x66, 0xd1, 0xcd, 0x56, 0x88, 0xed, 0x8e, 0x4d, 0x0c, 0x93, 0x95, 0x2f,
-	0x13, 0xcd, 0xf7, 0x4c, 0x69, 0xb2, 0x97, 0x56, 0x94, 0x70, 0xf8, 0x85, 0x85, 0x26, 0x9a, 0x8b,
-	0x1c, 0xb3, 0xd7, 0x92, 0x29, 0x8d, 0xae, 0x21, 0xc8, 0x48, 0x9e, 0xb3, 0x62, 0xcd, 0x69, 0xe8,
-	0xc4, 0xce, 0xb8, 0x83, 0x7d, 0x4b, 0x2c, 0x28, 0xfa, 0x0f, 0x5d, 0xb5, 0x13, 0xba, 0x1a, 0xb9,
-	0x66, 0xe4, 0x55, 0x70, 0x41, 0x51, 0x0c, 0x3d, 0xca, 0xd4, 0xa6, 0xe0, 0xb2, 0xda, 0x15, 0xb6,
-	0x62, 0x67, 0x1c, 0xe0, 0x26, 0x95, 0xbc, 0x3b, 0xf0, 0xfb, 0xe8, 0xa5, 0xa4, 0xc8, 0x15, 0x43,
-	0x43, 0x70, 0xbf, 0x5c, 0x5c, 0x4e, 0x4f, 0xcd, 0xdd, 0x9f, 0xcd, 0x5b, 0x97, 0xcc, 0xdb, 0xdf,
-	0xcc, 0xd1, 0x03, 0x04, 0x9b, 0x82, 0x11, 0xcd, 0xd6, 0x44, 0x87, 0x9d, 0xd8, 0x19, 0xf7, 0xa6,
-	0x51, 0x6a, 0xcb, 0x49, 0x0f, 0xe5, 0xa4, 0xab, 0x43, 0x39, 0xd8, 0xb7, 0xe2, 0x99, 0x4e, 0x1e,
-	0xc1, 0x5b, 0xb2, 0x1d, 0xdb, 0xe8, 0xa6, 0xbb, 0x73, 0xe2, 0x7e, 0x05, 0xfe, 0xb6, 0x10, 0xa5,
-	0x3c, 0x46, 0xee, 0x1a, 0xbc, 0xa0, 0x49, 0x08, 0xde, 0xdc, 0xa4, 0x3f, 0xff, 0xd1, 0xe4, 0x09,
-	0x60, 0x55, 0x90, 0x5c, 0x71, 0x13, 0xef, 0x62, 0xe7, 0x17, 0xf6, 0xc7, 0xe0, 0x2d, 0x35, 0xd1,
-	0xa5, 0x42, 0xff, 0xc0, 0x53, 0xe6, 0x65, 0x3e, 0x0f, 0x70, 0x8d, 0xa6, 0x1f, 0x0e, 0xf8, 0x87,
-	0xd6, 0xd1, 0x3d, 0x04, 0x33, 0x4a, 0xeb, 0x44, 0x7f, 0x52, 0x99, 0xa5, 0x67, 0xc7, 0x8f, 0xfe,
-	0x9e, 0x92, 0xf5, 0x95, 0x6e, 0x61, 0xb0, 0x64, 0xba, 0x91, 0x77, 0x58, 0xc9, 0x8e, 0x38, 0x82,
-	0x0a, 0xd7, 0x49, 0x46, 0xd0, 0xb7, 0x8d, 0xd5, 0x3e, 0x76, 0x66, 0x18, 0xab, 0xab, 0xf9, 0x11,
-	0xf4, 0x31, 0xdb, 0x8b, 0x37, 0xd6, 0xd4, 0xd9, 0x77, 0x73, 0xdf, 0xbc, 0xfd, 0xec, 0xca, 0x2c,
-	0xf3, 0xcc, 0x95, 0xee, 0x3e, 0x03, 0x00, 0x00, 0xff, 0xff, 0xbf, 0x05, 0xb2, 0x66, 0xe5, 0x02,
-	0x00, 0x00,
+	// 1552 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x92, 0xdb, 0x44,
+	0x10, 0x2e, 0xc9, 0xb6, 0x6c, 0xb5, 0xd7, 0x3f, 0x99, 0x0d, 0x8b, 0x50, 0xfe, 0x8c, 0x21, 0xc1,
+	0x14, 0x94, 0x37, 0x6c, 0x80, 0x84, 0x50, 0xa1, 0xd8, 0xdd, 0xa4, 0x52, 0x5b, 0x15, 0xaa, 0x52,
+	0x72, 0xc8, 0x81, 0x8b, 0x6b, 0x6c, 0x4d, 0xbc, 0xaa, 0x95, 0x25, 0x21, 0x8d, 0xbd, 0x31, 0x8f,
+	0x00, 0x67, 0xee, 0x1c, 0x79, 0x0f, 0xb8, 0xf1, 0x04, 0x3c, 0x00, 0x37, 0x1e, 0x82, 0x9a, 0x1f,
+	0xfd, 0x79, 0x57, 0xb2, 0x2b, 0x29, 0x6e, 0xea, 0xee, 0x6f, 0x7a, 0xa6, 0xbf, 0xe9, 0xee, 0x69,
+	0x1b, 0x5a, 0x38, 0x70, 0xf6, 0x71, 0xe0, 0x0c, 0x83, 0xd0, 0xa7, 0x3e, 0x52, 0x83, 0x89, 0x79,
+	0x6d, 0xe6, 0xfb, 0x33, 0x97, 0xec, 0x73, 0xcd, 0x64, 0xf1, 0x6a, 0x9f, 0xcc, 0x03, 0xba, 0x12,
+	0x00, 0xf3, 0xd6, 0xba, 0x91, 0x3a, 0x73, 0x12, 0x51, 0x3c, 0x0f, 0x24, 0xe0, 0xe6, 0x3a, 0xe0,
+	0x3c, 0xc4, 0x41, 0x40, 0xc2, 0x48, 0xd8, 0xfb, 0xbf, 0xd7, 0xa0, 0x63, 0xf9, 0x14, 0x53, 0xc7,
+	0xf7, 0x2c, 0xf2, 0xe3, 0x82, 0x44, 0x14, 0x5d, 0x03, 0x7d, 0x82, 0x3d, 0x8f, 0x84, 0x63, 0xc7,
+	0x36, 0x94, 0x9e, 0x32, 0xa8, 0x59, 0x0d, 0xa1, 0x38, 0xb1, 0xd1, 0xbb, 0x50, 0x8f, 0x5c, 0x9f,
+	0x32, 0x93, 0xca, 0x4d, 0x1a, 0x13, 0x4f, 0x6c, 0xd4, 0x83, 0xa6, 0x4d, 0xa2, 0x69, 0xe8, 0x04,
+	0xcc, 0x97, 0x51, 0xe9, 0x29, 0x03, 0xdd, 0xca, 0xaa, 0xd0, 0x55, 0xa8, 0x05, 0xa1, 0xe3, 0x87,
+	0x46, 0x95, 0xdb, 0x84, 0x80, 0x6e, 0x41, 0x93, 0x7f, 0x8c, 0x97, 0x0e, 0x39, 0x8f, 0x8c, 0x5a,
+	0x4f, 0x19, 0x28, 0x16, 0x70, 0xd5, 0x4b, 0xa6, 0x41, 0xef, 0xc3, 0x8e, 0x00, 0x4c, 0x5d, 0x67,
+	0x7a, 0x16, 0x19, 0x1a, 0x47, 0x88, 0x45, 0xc7, 0x5c, 0x85, 0xee, 0x83, 0x1e, 0x51, 0x1c, 0xd2,
+	0x68, 0x8c, 0xa9, 0x51, 0xef, 0x29, 0x83, 0xe6, 0x81, 0x39, 0x14, 0x91, 0x0f, 0xe3, 0xc8, 0x87,
+	0x2f, 0x62, 0x6a, 0xac, 0x86, 0x00, 0x1f, 0x52, 0x74, 0x0f, 0xea, 0xc4, 0xb3, 0xf9, 0xb2, 0xc6,
+	0xc6, 0x65, 0x1a, 0x83, 0x1e, 0x52, 0x64, 0x42, 0x83, 0xd1, 0xfc, 0x93, 0xef, 0x11, 0x43, 0xe7,
+	0xa1, 0x24, 0x32, 0xda, 0x07, 0xb0, 0xf1, 0x2a, 0xc0, 0x21, 0x75, 0xbc, 0x99, 0x01, 0xbd, 0xca,
+	0xa0, 0x79, 0xd0, 0x19, 0x06, 0x93, 0xe1, 0x63, 0xa1, 0xb5, 0x16, 0x2e, 0xb1, 0x32, 0x10, 0xb4,
+	0x07, 0xda, 0x39, 0x71, 0x66, 0xa7, 0xd4, 0x68, 0xf2, 0xb8, 0xa4, 0xc4, 0x2e, 0x61, 0xee, 0x78,
+	0xe3, 0xe8, 0x14, 0x87, 0xc4, 0xd8, 0xe1, 0xa6, 0xc6, 0xdc, 0xf1, 0x46, 0x4c, 0xe6, 0x46, 0xfc,
+	0x5a, 0x1a, 0x5b, 0xd2, 0x88, 0x5f, 0x27, 0x46, 0x4e, 0xe5, 0x78, 0x8a, 0x03, 0xa3, 0x2d, 0xae,
+	0x8f, 0x2b, 0x8e, 0x71, 0x80, 0x6e, 0x00, 0x08, 0x1a, 0xb9, 0xb5, 0xc3, 0xad, 0xba, 0xd0, 0x30,
+	0xf3, 0x1d, 0xe8, 0xd8, 0xd8, 0x71, 0x57, 0xe3, 0xd4, 0x43, 0x97, 0x63, 0x5a, 0x5c, 0xfd, 0x32,
+	0x76, 0x33, 0x80, 0xae, 0xc0, 0x65, 0x9c, 0x5d, 0xe1, 0xc0, 0x36, 0xd7, 0x1f, 0x27, 0x1e, 0xf7,
+	0x40, 0x0b, 0xf0, 0x94, 0x91, 0x81, 0x7a, 0xca, 0xa0, 0x61, 0x49, 0x09, 0xdd, 0x05, 0x9d, 0xe2,
+	0x70, 0x46, 0x38, 0x4f, 0xbb, 0x9c, 0x7b, 0xc4, 0x78, 0x7a, 0x11, 0x2b, 0x19, 0x53, 0x91, 0x95,
+	0x82, 0xfa, 0x3f, 0x6b, 0xd0, 0x4d, 0x53, 0x35, 0x0a, 0x7c, 0x2f, 0x22, 0xa8, 0x0d, 0x6a, 0x92,
+	0xa4, 0xaa, 0x63, 0xe7, 0x73, 0x57, 0x2d, 0xce, 0xdd, 0x4a, 0x59, 0xee, 0x56, 0x2f, 0xe6, 0xee,
+	0x7d, 0xd0, 0xa7, 0x21, 0xc1, 0x94, 0xb0, 0x54, 0xa9, 0x6d, 0xce, 0x30, 0x01, 0x3e, 0xa4, 0x69,
+	0xd2, 0x6b, 0x25, 0x49, 0x5f, 0xdf, 0x98, 0xf4, 0x8d, 0x0d, 0x49, 0xaf, 0xbf, 0x59, 0xd2, 0xc3,
+	0x1b, 0x25, 0x7d, 0xb3, 0x34, 0xe9, 0x77, 0xb6, 0x4a, 0xfa, 0x88, 0x62, 0xba, 0x88, 0x78, 0xf2,
+	0xea, 0x96, 0x94, 0x32, 0xc5, 0xd0, 0x2e, 0x2e, 0x86, 0x4e, 0x59, 0x31, 0x74, 0xcb, 0x8a, 0xe1,
+	0x4a, 0x69, 0x31, 0xa0, 0x2d, 0x8a, 0x61, 0x77, 0xdb, 0x62, 0xb8, 0xba, 0xa1, 0x18, 0xde, 0x29,
+	0x2e, 0x86, 0xbd, 0x6d, 0x8a, 0x61, 0x09, 0xb5, 0x24, 0xc0, 0xe2, 0x66, 0x9d, 0xf2, 0xa9, 0x16,
+	0xf3, 0x59, 0x29, 0xe3, 0xb3, 0x9a, 0xe7, 0xb3, 0xff, 0x97, 0x02, 0xda, 0xd1, 0xc2, 0x9e, 0x91,
+	0x0d, 0xcf, 0x44, 0x8e, 0x77, 0xb5, 0x94, 0xf7, 0xca, 0x16, 0xbc, 0x57, 0xb7, 0xe5, 0xbd, 0xb6,
+	0x81, 0x77, 0x2d, 0xcb, 0x7b, 0xff, 0x37, 0x15, 0xda, 0x79, 0x8e, 0x19, 0x74, 0x16, 0xfa, 0x8b,
+	0x20, 0x32, 0x94, 0x5e, 0x85, 0xb5, 0x08, 0x21, 0xa1, 0x0f, 0xa0, 0x65, 0x13, 0xcf, 0x21, 0xf6,
+	0x58, 0x9a, 0x55, 0x6e, 0xde, 0x11, 0xca, 0xa7, 0x02, 0x74, 0x1d, 0xf4, 0xa9, 0xbf, 0xf0, 0x68,
+	0xe8, 0x90, 0xc8, 0xa8, 0xf4, 0x2a, 0x03, 0xdd, 0x4a, 0x15, 0xe8, 0x63, 0xe8, 0x4a, 0x17, 0x29,
+	0xa8, 0xca, 0x41, 0x1d, 0xa1, 0x3f, 0x4e, 0xa0, 0x06, 0xd4, 0x6d, 0xb2, 0x74, 0xa6, 0x84, 0x3d,
+	0x88, 0x0c, 0x11, 0x8b, 0xe8, 0x36, 0xb4, 0xa5, 0x93, 0x18, 0xa0, 0x71, 0x80, 0x3c, 0xdd, 0x63,
+	0x09, 0xbb, 0x0e, 0xba, 0x8b, 0xbd, 0xd9, 0x02, 0xcf, 0x08, 0x6b, 0x2f, 0xfc, 0x24, 0x89, 0x22,
+	0x73, 0x92, 0x14, 0xd4, 0xc8, 0x9e, 0xe4, 0x59, 0xac, 0xee, 0x5b, 0xa0, 0x27, 0x0c, 0x95, 0x5f,
+	0xf9, 0x00, 0x6a, 0x21, 0xa3, 0xd0, 0x50, 0x0b, 0x13, 0x58, 0x00, 0xfa, 0xdf, 0x41, 0x33, 0xd3,
+	0x19, 0x58, 0x6b, 0x39, 0x27, 0xe4, 0xcc, 0xc6, 0xab, 0x98, 0xf4, 0x44, 0x46, 0x08, 0xaa, 0xaf,
+	0x42, 0x7f, 0x2e, 0x53, 0x88, 0x7f, 0xb3, 0x9e, 0x4f, 0x7d, 0x99, 0x36, 0x2a, 0xf5, 0xfb, 0x2e,
+	0xc0, 0xe3, 0xb4, 0xb7, 0x94, 0x9e, 0x31, 0xdb, 0xc5, 0xd4, 0xb5, 0x2e, 0x76, 0x3b, 0x3e, 0x7f,
+	0xe5, 0xf2, 0x06, 0x26, 0x0f, 0xff, 0xab, 0x02, 0x8d, 0xd1, 0xf4, 0x94, 0xd8, 0xec, 0xe8, 0xa5,
+	0x9b, 0xe5, 0x1a, 0xb4, 0xfa, 0x66, 0x0d, 0xba, 0xb2, 0x6d, 0x83, 0xee, 0xff, 0xab, 0x80, 0x36,
+	0x22, 0x2e, 0x99, 0xd2, 0xec, 0x3b, 0xa7, 0xe4, 0xde, 0xb9, 0xf7, 0xa0, 0xc1, 0xb3, 0x37, 0x7d,
+	0x1c, 0xeb, 0x5c, 0x3e, 0xb1, 0xd1, 0x43, 0x00, 0x4c, 0x69, 0xe8, 0x4c, 0x16, 0x34, 0xa1, 0xc0,
+	0x64, 0x14, 0x08, 0x9f, 0xc3, 0xc3, 0xc4, 0xf8, 0xc4, 0xa3, 0xe1, 0xca, 0xca, 0xa0, 0xd9, 0x1b,
+	0xc7, 0x33, 0x5a, 0x96, 0xa9, 0x10, 0x58, 0x95, 0x2f, 0x9d, 0xc8, 0xa1, 0x3e, 0x27, 0xa7, 0xc6,
+	0xd9, 0xd6, 0xa5, 0xe6, 0xc4, 0x36, 0x1f, 0x41, 0x67, 0xcd, 0x27, 0xea, 0x42, 0xe5, 0x8c, 0xac,
+	0xf8, 0x99, 0x75, 0x8b, 0x7d, 0x32, 0xcf, 0x4b, 0xec, 0x2e, 0xe2, 0xcb, 0x12, 0xc2, 0x43, 0xf5,
+	0x81, 0xd2, 0x37, 0x40, 0x3b, 0xe2, 0x44, 0xaf, 0x8f, 0x00, 0xfd, 0x7d, 0xa8, 0x0b, 0x4b, 0x84,
+	0x3e, 0x84, 0xba, 0xb8, 0x0d, 0x91, 0x58, 0xcd, 0x03, 0x60, 0x11, 0x09, 0xab, 0x15, 0x9b, 0xfa,
+	0x7f, 0x28, 0x00, 0x2f, 0x42, 0xec, 0x45, 0x0e, 0x7f, 0xea, 0x4b, 0xef, 0xb4, 0x84, 0xc1, 0x6f,
+	0x2e, 0x61, 0xf0, 0x26, 0x2f, 0x82, 0xc4, 0x77, 0x19, 0x8b, 0x6f, 0x4b, 0xc8, 0xdf, 0x0a, 0xc0,
+	0xb1, 0xef, 0x2d, 0x49, 0x18, 0xbd, 0x4d, 0x14, 0xc9, 0x06, 0xe2, 0x59, 0x10, 0xc2, 0x5a, 0x6c,
+	0xd5, 0x34, 0xb6, 0x74, 0xc7, 0xff, 0x33, 0xb6, 0x1e, 0x68, 0xa3, 0x64, 0x42, 0x90, 0x93, 0x83,
+	0x92, 0x9d, 0x1c, 0xfa, 0x7f, 0x2a, 0xb0, 0x33, 0x72, 0x7d, 0x3a, 0xa2, 0x21, 0xa6, 0x64, 0xb6,
+	0x2a, 0xae, 0x01, 0x13, 0x1a, 0x91, 0x04, 0xc5, 0x2d, 0x20, 0x96, 0xd1, 0xb7, 0x00, 0x01, 0x0e,
+	0xf1, 0x9c, 0x50, 0x12, 0xc6, 0x57, 0xd8, 0xe3, 0x45, 0x90, 0x71, 0x3d, 0x7c, 0x9e, 0x40, 0x64,
+	0xa0, 0xe9, 0x1a, 0x16, 0xe8, 0x9a, 0x79, 0x53, 0xa0, 0x4a, 0x36, 0xd0, 0x47, 0x00, 0x6c, 0x2b,
+	0x8b, 0x9c, 0xe3, 0xd0, 0x2e, 0x8e, 0x61, 0x0f, 0xb4, 0x90, 0x43, 0x64, 0x04, 0x52, 0xea, 0xff,
+	0x52, 0x81, 0x2a, 0x5b, 0x7f, 0x61, 0x2c, 0x46, 0x50, 0xf5, 0xf0, 0x3c, 0x66, 0x96, 0x7f, 0x6f,
+	0xf7, 0x83, 0xed, 0xdc, 0xb1, 0xe9, 0x69, 0x5c, 0xd7, 0x5c, 0x60, 0x9b, 0x9f, 0x8a, 0xa1, 0x42,
+	0x3c, 0xb6, 0x52, 0xca, 0x11, 0xab, 0xad, 0x11, 0xfb, 0x20, 0x47, 0x6c, 0x9d, 0x13, 0x6b, 0xc4,
+	0xc4, 0x96, 0x11, 0x9a, 0x09, 0xb5, 0x91, 0x0d, 0x15, 0x7d, 0x0a, 0xe8, 0x15, 0x76, 0xdd, 0x09,
+	0x9e, 0x9e, 0x8d, 0xd3, 0x44, 0xd7, 0xf9, 0x89, 0xba, 0xb1, 0xe5, 0x28, 0x4e, 0xf8, 0xcf, 0x59,
+	0x47, 0xc5, 0x13, 0x97, 0xd8, 0x85, 0x23, 0xef, 0x91, 0xef, 0xbb, 0x2f, 0xd9, 0x45, 0x58, 0x31,
+	0xf4, 0x6d, 0x2f, 0xd3, 0x00, 0x6d, 0x24, 0xee, 0x6b, 0xbd, 0x45, 0x7d, 0x04, 0x35, 0x66, 0x89,
+	0xd0, 0x4d, 0xa8, 0xb1, 0x2b, 0x8d, 0xdb, 0x53, 0x23, 0xa6, 0xc4, 0x12, 0xea, 0x83, 0x7f, 0xea,
+	0xd0, 0x88, 0x7f, 0xf3, 0xa0, 0x2f, 0x41, 0x3f, 0xb4, 0x6d, 0xd9, 0xf5, 0x76, 0x19, 0x74, 0xed,
+	0x97, 0xbb, 0x79, 0x35, 0xaf, 0x94, 0xbf, 0x91, 0x3e, 0x81, 0xd6, 0x88, 0xd0, 0x4c, 0x87, 0x6b,
+	0xe7, 0xbb, 0x92, 0xc9, 0xbb, 0xa2, 0x2c, 0x30, 0x01, 0xce, 0x34, 0x92, 0x76, 0xbe, 0xcc, 0x73,
+	0xe0, 0x3b, 0xb0, 0x23, 0x9e, 0x07, 0x79, 0x28, 0x48, 0x1f, 0x0c, 0x33, 0xd3, 0x6a, 0xd1, 0x00,
+	0x5a, 0x42, 0x1b, 0x37, 0xe6, 0x2c, 0xb0, 0x99, 0x02, 0xb9, 0x47, 0x8b, 0xcc, 0xfd, 0x25, 0xc9,
+	0x7a, 0x14, 0xdf, 0xb9, 0x9d, 0xf7, 0xa1, 0xf9, 0x1c, 0x2f, 0xa2, 0xcb, 0x60, 0x97, 0x93, 0x70,
+	0x97, 0x39, 0x8e, 0x16, 0xf3, 0xed, 0x57, 0x7c, 0x06, 0xad, 0xc3, 0x70, 0x7a, 0xea, 0x2c, 0xb7,
+	0x5f, 0x72, 0x1f, 0xae, 0x8c, 0x88, 0x0c, 0x32, 0x99, 0x11, 0x76, 0xf8, 0xb1, 0xa5, 0x54, 0xb0,
+	0xf0, 0x6b, 0xd8, 0x4d, 0x16, 0x66, 0x66, 0x99, 0x76, 0x66, 0x06, 0x71, 0xbc, 0x59, 0xe1, 0x41,
+	0xdb, 0xe9, 0xae, 0x7c, 0x84, 0xd7, 0xf9, 0x96, 0xec, 0xb3, 0x60, 0xc9, 0x3d, 0xe8, 0x24, 0x4b,
+	0xe4, 0x38, 0x2f, 0xa2, 0xe3, 0xdf, 0x05, 0x8b, 0xbe, 0x02, 0x94, 0x2c, 0x4a, 0x67, 0xc2, 0x56,
+	0x6e, 0xce, 0x2b, 0x58, 0xfa, 0x05, 0xdf, 0x2f, 0xd7, 0xa0, 0xbb, 0xeb, 0x7d, 0xd5, 0xbc, 0xa0,
+	0x41, 0xfb, 0x3c, 0x19, 0x33, 0x1d, 0xb1, 0x9d, 0x14, 0x08, 0x97, 0xcd, 0x35, 0x19, 0xdd, 0x80,
+	0xfa, 0xa1, 0x6d, 0x33, 0x05, 0x4a, 0x6a, 0xc9, 0x4c, 0xbe, 0x50, 0x0f, 0xe0, 0xfb, 0xc0, 0xc6,
+	0x94, 0x14, 0x22, 0x6e, 0x41, 0xfd, 0xa9, 0xd8, 0x51, 0xe6, 0x28, 0x2f, 0xe0, 0x0c, 0x60, 0x08,
+	0xfa, 0x33, 0x27, 0xa2, 0xa2, 0x7c, 0xf7, 0x2e, 0x74, 0x91, 0x27, 0xec, 0xcf, 0x39, 0x53, 0x8f,
+	0xe1, 0x6c, 0x04, 0x01, 0x91, 0xd0, 0x17, 0x7c, 0x66, 0xd2, 0xf9, 0xa8, 0xfa, 0x83, 0x1a, 0x4c,
+	0x26, 0x1a, 0x77, 0x73, 0xef, 0xbf, 0x01, 0x00, 0x5c, 0xcd, 0x9b, 0xaf, 0x06, 0x14, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RotationClient is the client API for Rotation service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RotationClient interface {
+	// Adds a banner in the rotation
+	AddBanner(ctx context.Context, in *RotationRequest, opts ...grpc.CallOption) (*RotationResponse, error)
+	// Sets the transition on the banner
+	SetTransition(ctx context.Context, in *Transition, opts ...grpc.CallOption) (*Status, error)
+	// Records the conversion of the banner with its value
+	SetConversion(ctx context.Context, in *Conversion, opts ...grpc.CallOption) (*Status, error)
+	// Selects a banner to display
+	SelectBanner(ctx context.Context, in *Select, opts ...grpc.CallOption) (*Banner, error)
+	// Selects count distinct banners to display ranked by the strategy
+	SelectBanners(ctx context.Context, in *Select, opts ...grpc.CallOption) (*Banners, error)
+	// Removes the banner from the rotation
+	RemoveBanner(ctx context.Context, in *Banner, opts ...grpc.CallOption) (*Status, error)
+	// Pauses the banner, its statistics are kept
+	PauseBanner(ctx context.Context, in *Banner, opts ...grpc.CallOption) (*RotationResponse, error)
+	// Resumes the paused banner
+	ResumeBanner(ctx context.Context, in *Banner, opts ...grpc.CallOption) (*RotationResponse, error)
+	// Archives the banner, its statistics are kept
+	ArchiveBanner(ctx context.Context, in *Banner, opts ...grpc.CallOption) (*RotationResponse, error)
+	// Sets when the banner joins and leaves the slot
+	SetBannerSchedule(ctx context.Context, in *Schedule, opts ...grpc.CallOption) (*RotationResponse, error)
+	// Sets the hours and the weekdays the banner is selected at
+	SetBannerDayparting(ctx context.Context, in *Dayparting, opts ...grpc.CallOption) (*RotationResponse, error)
+	// Sets the weight of the banner and the bounds of its share of the views of the slot
+	SetBannerShare(ctx context.Context, in *Share, opts ...grpc.CallOption) (*RotationResponse, error)
+	// Sets the total and daily caps of the views and clicks of the banner
+	SetBannerBudget(ctx context.Context, in *Budget, opts ...grpc.CallOption) (*RotationResponse, error)
+	// Sets the groups and the attributes of the requests the banner is selected for
+	SetBannerTargeting(ctx context.Context, in *Targeting, opts ...grpc.CallOption) (*RotationResponse, error)
+	// Sets the strategy that selects banners in the slot
+	SetSlotStrategy(ctx context.Context, in *SlotStrategy, opts ...grpc.CallOption) (*SlotStrategy, error)
+	// Sets what the strategy of the slot maximizes: clicks or conversions
+	SetSlotReward(ctx context.Context, in *SlotReward, opts ...grpc.CallOption) (*SlotReward, error)
+	// Adds a new slot with its settings
+	AddSlot(ctx context.Context, in *Slot, opts ...grpc.CallOption) (*Slot, error)
+	// Replaces the settings of the slot
+	UpdateSlot(ctx context.Context, in *Slot, opts ...grpc.CallOption) (*Slot, error)
+	// Returns the slot with its settings
+	GetSlot(ctx context.Context, in *SlotId, opts ...grpc.CallOption) (*Slot, error)
+	// Returns all the slots with settings
+	ListSlots(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Slots, error)
+	// Removes the settings of the slot
+	RemoveSlot(ctx context.Context, in *SlotId, opts ...grpc.CallOption) (*Status, error)
+}
+
+type rotationClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRotationClient(cc *grpc.ClientConn) RotationClient {
+	return &rotationClient{cc}
+}
+
+func (c *rotationClient) AddBanner(ctx context.Context, in *RotationRequest, opts ...grpc.CallOption) (*RotationResponse, error) {
+	out := new(RotationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/AddBanner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (c *rotationClient) SetTransition(ctx context.Context, in *Transition, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SetTransition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (c *rotationClient) SetConversion(ctx context.Context, in *Conversion, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SetConversion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// RotationClient is the client API for Rotation service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type RotationClient interface {
+func (c *rotationClient) SelectBanner(ctx context.Context, in *Select, opts ...grpc.CallOption) (*Banner, error) {
+	out := new(Banner)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SelectBanner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) SelectBanners(ctx context.Context, in *Select, opts ...grpc.CallOption) (*Banners, error) {
+	out := new(Banners)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SelectBanners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) RemoveBanner(ctx context.Context, in *Banner, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/RemoveBanner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) PauseBanner(ctx context.Context, in *Banner, opts ...grpc.CallOption) (*RotationResponse, error) {
+	out := new(RotationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/PauseBanner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) ResumeBanner(ctx context.Context, in *Banner, opts ...grpc.CallOption) (*RotationResponse, error) {
+	out := new(RotationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/ResumeBanner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) ArchiveBanner(ctx context.Context, in *Banner, opts ...grpc.CallOption) (*RotationResponse, error) {
+	out := new(RotationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/ArchiveBanner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) SetBannerSchedule(ctx context.Context, in *Schedule, opts ...grpc.CallOption) (*RotationResponse, error) {
+	out := new(RotationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SetBannerSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) SetBannerDayparting(ctx context.Context, in *Dayparting, opts ...grpc.CallOption) (*RotationResponse, error) {
+	out := new(RotationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SetBannerDayparting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) SetBannerShare(ctx context.Context, in *Share, opts ...grpc.CallOption) (*RotationResponse, error) {
+	out := new(RotationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SetBannerShare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) SetBannerBudget(ctx context.Context, in *Budget, opts ...grpc.CallOption) (*RotationResponse, error) {
+	out := new(RotationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SetBannerBudget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) SetBannerTargeting(ctx context.Context, in *Targeting, opts ...grpc.CallOption) (*RotationResponse, error) {
+	out := new(RotationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SetBannerTargeting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) SetSlotStrategy(ctx context.Context, in *SlotStrategy, opts ...grpc.CallOption) (*SlotStrategy, error) {
+	out := new(SlotStrategy)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SetSlotStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) SetSlotReward(ctx context.Context, in *SlotReward, opts ...grpc.CallOption) (*SlotReward, error) {
+	out := new(SlotReward)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/SetSlotReward", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) AddSlot(ctx context.Context, in *Slot, opts ...grpc.CallOption) (*Slot, error) {
+	out := new(Slot)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/AddSlot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) UpdateSlot(ctx context.Context, in *Slot, opts ...grpc.CallOption) (*Slot, error) {
+	out := new(Slot)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/UpdateSlot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) GetSlot(ctx context.Context, in *SlotId, opts ...grpc.CallOption) (*Slot, error) {
+	out := new(Slot)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/GetSlot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) ListSlots(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Slots, error) {
+	out := new(Slots)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/ListSlots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rotationClient) RemoveSlot(ctx context.Context, in *SlotId, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/pb.Rotation/RemoveSlot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RotationServer is the server API for Rotation service.
+type RotationServer interface {
 	// Adds a banner in the rotation
-	AddBanner(ctx context.Context, in *RotationRequest, opts ...grpc.CallOption) (*RotationResponse, error)
+	AddBanner(context.Context, *RotationRequest) (*RotationResponse, error)
 	// Sets the transition on the banner
-	SetTransition(ctx context.Context, in *Transition, opts ...grpc.CallOption) (*Status, error)
+	SetTransition(context.Context, *Transition) (*Status, error)
+	// Records the conversion of the banner with its value
+	SetConversion(context.Context, *Conversion) (*Status, error)
 	// Selects a banner to display
-	SelectBanner(ctx context.Context, in *Select, opts ...grpc.CallOption) (*Banner, error)
+	SelectBanner(context.Context, *Select) (*Banner, error)
+	// Selects count distinct banners to display ranked by the strategy
+	SelectBanners(context.Context, *Select) (*Banners, error)
 	// Removes the banner from the rotation
-	RemoveBanner(ctx context.Context, in *Banner, opts ...grpc.CallOption) (*Status, error)
+	RemoveBanner(context.Context, *Banner) (*Status, error)
+	// Pauses the banner, its statistics are kept
+	PauseBanner(context.Context, *Banner) (*RotationResponse, error)
+	// Resumes the paused banner
+	ResumeBanner(context.Context, *Banner) (*RotationResponse, error)
+	// Archives the banner, its statistics are kept
+	ArchiveBanner(context.Context, *Banner) (*RotationResponse, error)
+	// Sets when the banner joins and leaves the slot
+	SetBannerSchedule(context.Context, *Schedule) (*RotationResponse, error)
+	// Sets the hours and the weekdays the banner is selected at
+	SetBannerDayparting(context.Context, *Dayparting) (*RotationResponse, error)
+	// Sets the weight of the banner and the bounds of its share of the views of the slot
+	SetBannerShare(context.Context, *Share) (*RotationResponse, error)
+	// Sets the total and daily caps of the views and clicks of the banner
+	SetBannerBudget(context.Context, *Budget) (*RotationResponse, error)
+	// Sets the groups and the attributes of the requests the banner is selected for
+	SetBannerTargeting(context.Context, *Targeting) (*RotationResponse, error)
+	// Sets the strategy that selects banners in the slot
+	SetSlotStrategy(context.Context, *SlotStrategy) (*SlotStrategy, error)
+	// Sets what the strategy of the slot maximizes: clicks or conversions
+	SetSlotReward(context.Context, *SlotReward) (*SlotReward, error)
+	// Adds a new slot with its settings
+	AddSlot(context.Context, *Slot) (*Slot, error)
+	// Replaces the settings of the slot
+	UpdateSlot(context.Context, *Slot) (*Slot, error)
+	// Returns the slot with its settings
+	GetSlot(context.Context, *SlotId) (*Slot, error)
+	// Returns all the slots with settings
+	ListSlots(context.Context, *empty.Empty) (*Slots, error)
+	// Removes the settings of the slot
+	RemoveSlot(context.Context, *SlotId) (*Status, error)
+}
+
+// UnimplementedRotationServer can be embedded to have forward compatible implementations.
+type UnimplementedRotationServer struct {
+}
+
+func (*UnimplementedRotationServer) AddBanner(ctx context.Context, req *RotationRequest) (*RotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBanner not implemented")
+}
+func (*UnimplementedRotationServer) SetTransition(ctx context.Context, req *Transition) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTransition not implemented")
+}
+func (*UnimplementedRotationServer) SetConversion(ctx context.Context, req *Conversion) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConversion not implemented")
+}
+func (*UnimplementedRotationServer) SelectBanner(ctx context.Context, req *Select) (*Banner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelectBanner not implemented")
+}
+func (*UnimplementedRotationServer) SelectBanners(ctx context.Context, req *Select) (*Banners, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelectBanners not implemented")
+}
+func (*UnimplementedRotationServer) RemoveBanner(ctx context.Context, req *Banner) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveBanner not implemented")
+}
+func (*UnimplementedRotationServer) PauseBanner(ctx context.Context, req *Banner) (*RotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseBanner not implemented")
+}
+func (*UnimplementedRotationServer) ResumeBanner(ctx context.Context, req *Banner) (*RotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeBanner not implemented")
+}
+func (*UnimplementedRotationServer) ArchiveBanner(ctx context.Context, req *Banner) (*RotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveBanner not implemented")
+}
+func (*UnimplementedRotationServer) SetBannerSchedule(ctx context.Context, req *Schedule) (*RotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBannerSchedule not implemented")
+}
+func (*UnimplementedRotationServer) SetBannerDayparting(ctx context.Context, req *Dayparting) (*RotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBannerDayparting not implemented")
+}
+func (*UnimplementedRotationServer) SetBannerShare(ctx context.Context, req *Share) (*RotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBannerShare not implemented")
+}
+func (*UnimplementedRotationServer) SetBannerBudget(ctx context.Context, req *Budget) (*RotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBannerBudget not implemented")
+}
+func (*UnimplementedRotationServer) SetBannerTargeting(ctx context.Context, req *Targeting) (*RotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBannerTargeting not implemented")
+}
+func (*UnimplementedRotationServer) SetSlotStrategy(ctx context.Context, req *SlotStrategy) (*SlotStrategy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSlotStrategy not implemented")
+}
+func (*UnimplementedRotationServer) SetSlotReward(ctx context.Context, req *SlotReward) (*SlotReward, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSlotReward not implemented")
+}
+func (*UnimplementedRotationServer) AddSlot(ctx context.Context, req *Slot) (*Slot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSlot not implemented")
+}
+func (*UnimplementedRotationServer) UpdateSlot(ctx context.Context, req *Slot) (*Slot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSlot not implemented")
+}
+func (*UnimplementedRotationServer) GetSlot(ctx context.Context, req *SlotId) (*Slot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlot not implemented")
+}
+func (*UnimplementedRotationServer) ListSlots(ctx context.Context, req *empty.Empty) (*Slots, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSlots not implemented")
+}
+func (*UnimplementedRotationServer) RemoveSlot(ctx context.Context, req *SlotId) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSlot not implemented")
+}
+
+func RegisterRotationServer(s *grpc.Server, srv RotationServer) {
+	s.RegisterService(&_Rotation_serviceDesc, srv)
+}
+
+func _Rotation_AddBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).AddBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/AddBanner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).AddBanner(ctx, req.(*RotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rotation_SetTransition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Transition)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).SetTransition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SetTransition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SetTransition(ctx, req.(*Transition))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rotation_SetConversion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Conversion)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).SetConversion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SetConversion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SetConversion(ctx, req.(*Conversion))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rotation_SelectBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Select)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).SelectBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SelectBanner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SelectBanner(ctx, req.(*Select))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type rotationClient struct {
-	cc *grpc.ClientConn
+func _Rotation_SelectBanners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Select)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).SelectBanners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SelectBanners",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SelectBanners(ctx, req.(*Select))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewRotationClient(cc *grpc.ClientConn) RotationClient {
-	return &rotationClient{cc}
+func _Rotation_RemoveBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Banner)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).RemoveBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/RemoveBanner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).RemoveBanner(ctx, req.(*Banner))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *rotationClient) AddBanner(ctx context.Context, in *RotationRequest, opts ...grpc.CallOption) (*RotationResponse, error) {
-	out := new(RotationResponse)
-	err := c.cc.Invoke(ctx, "/pb.Rotation/AddBanner", in, out, opts...)
-	if err != nil {
+func _Rotation_PauseBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Banner)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RotationServer).PauseBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/PauseBanner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).PauseBanner(ctx, req.(*Banner))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *rotationClient) SetTransition(ctx context.Context, in *Transition, opts ...grpc.CallOption) (*Status, error) {
-	out := new(Status)
-	err := c.cc.Invoke(ctx, "/pb.Rotation/SetTransition", in, out, opts...)
-	if err != nil {
+func _Rotation_ResumeBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Banner)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RotationServer).ResumeBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/ResumeBanner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).ResumeBanner(ctx, req.(*Banner))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *rotationClient) SelectBanner(ctx context.Context, in *Select, opts ...grpc.CallOption) (*Banner, error) {
-	out := new(Banner)
-	err := c.cc.Invoke(ctx, "/pb.Rotation/SelectBanner", in, out, opts...)
-	if err != nil {
+func _Rotation_ArchiveBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Banner)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RotationServer).ArchiveBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/ArchiveBanner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).ArchiveBanner(ctx, req.(*Banner))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *rotationClient) RemoveBanner(ctx context.Context, in *Banner, opts ...grpc.CallOption) (*Status, error) {
-	out := new(Status)
-	err := c.cc.Invoke(ctx, "/pb.Rotation/RemoveBanner", in, out, opts...)
-	if err != nil {
+func _Rotation_SetBannerSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Schedule)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RotationServer).SetBannerSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SetBannerSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SetBannerSchedule(ctx, req.(*Schedule))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// RotationServer is the server API for Rotation service.
-type RotationServer interface {
-	// Adds a banner in the rotation
-	AddBanner(context.Context, *RotationRequest) (*RotationResponse, error)
-	// Sets the transition on the banner
-	SetTransition(context.Context, *Transition) (*Status, error)
-	// Selects a banner to display
-	SelectBanner(context.Context, *Select) (*Banner, error)
-	// Removes the banner from the rotation
-	RemoveBanner(context.Context, *Banner) (*Status, error)
+func _Rotation_SetBannerDayparting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Dayparting)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).SetBannerDayparting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SetBannerDayparting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SetBannerDayparting(ctx, req.(*Dayparting))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedRotationServer can be embedded to have forward compatible implementations.
-type UnimplementedRotationServer struct {
+func _Rotation_SetBannerShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Share)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).SetBannerShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SetBannerShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SetBannerShare(ctx, req.(*Share))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (*UnimplementedRotationServer) AddBanner(ctx context.Context, req *RotationRequest) (*RotationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddBanner not implemented")
+func _Rotation_SetBannerBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Budget)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).SetBannerBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SetBannerBudget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SetBannerBudget(ctx, req.(*Budget))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedRotationServer) SetTransition(ctx context.Context, req *Transition) (*Status, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetTransition not implemented")
+
+func _Rotation_SetBannerTargeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Targeting)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).SetBannerTargeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SetBannerTargeting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SetBannerTargeting(ctx, req.(*Targeting))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedRotationServer) SelectBanner(ctx context.Context, req *Select) (*Banner, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SelectBanner not implemented")
+
+func _Rotation_SetSlotStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlotStrategy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).SetSlotStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SetSlotStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SetSlotStrategy(ctx, req.(*SlotStrategy))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedRotationServer) RemoveBanner(ctx context.Context, req *Banner) (*Status, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveBanner not implemented")
+
+func _Rotation_SetSlotReward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlotReward)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).SetSlotReward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/SetSlotReward",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).SetSlotReward(ctx, req.(*SlotReward))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterRotationServer(s *grpc.Server, srv RotationServer) {
-	s.RegisterService(&_Rotation_serviceDesc, srv)
+func _Rotation_AddSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Slot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RotationServer).AddSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Rotation/AddSlot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RotationServer).AddSlot(ctx, req.(*Slot))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Rotation_AddBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RotationRequest)
+func _Rotation_UpdateSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Slot)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RotationServer).AddBanner(ctx, in)
+		return srv.(RotationServer).UpdateSlot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Rotation/AddBanner",
+		FullMethod: "/pb.Rotation/UpdateSlot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RotationServer).AddBanner(ctx, req.(*RotationRequest))
+		return srv.(RotationServer).UpdateSlot(ctx, req.(*Slot))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rotation_SetTransition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Transition)
+func _Rotation_GetSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlotId)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RotationServer).SetTransition(ctx, in)
+		return srv.(RotationServer).GetSlot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Rotation/SetTransition",
+		FullMethod: "/pb.Rotation/GetSlot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RotationServer).SetTransition(ctx, req.(*Transition))
+		return srv.(RotationServer).GetSlot(ctx, req.(*SlotId))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rotation_SelectBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Select)
+func _Rotation_ListSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RotationServer).SelectBanner(ctx, in)
+		return srv.(RotationServer).ListSlots(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Rotation/SelectBanner",
+		FullMethod: "/pb.Rotation/ListSlots",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RotationServer).SelectBanner(ctx, req.(*Select))
+		return srv.(RotationServer).ListSlots(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rotation_RemoveBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Banner)
+func _Rotation_RemoveSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlotId)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RotationServer).RemoveBanner(ctx, in)
+		return srv.(RotationServer).RemoveSlot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Rotation/RemoveBanner",
+		FullMethod: "/pb.Rotation/RemoveSlot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RotationServer).RemoveBanner(ctx, req.(*Banner))
+		return srv.(RotationServer).RemoveSlot(ctx, req.(*SlotId))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -545,14 +2355,82 @@ var _Rotation_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetTransition",
 			Handler:    _Rotation_SetTransition_Handler,
 		},
+		{
+			MethodName: "SetConversion",
+			Handler:    _Rotation_SetConversion_Handler,
+		},
 		{
 			MethodName: "SelectBanner",
 			Handler:    _Rotation_SelectBanner_Handler,
 		},
+		{
+			MethodName: "SelectBanners",
+			Handler:    _Rotation_SelectBanners_Handler,
+		},
 		{
 			MethodName: "RemoveBanner",
 			Handler:    _Rotation_RemoveBanner_Handler,
 		},
+		{
+			MethodName: "PauseBanner",
+			Handler:    _Rotation_PauseBanner_Handler,
+		},
+		{
+			MethodName: "ResumeBanner",
+			Handler:    _Rotation_ResumeBanner_Handler,
+		},
+		{
+			MethodName: "ArchiveBanner",
+			Handler:    _Rotation_ArchiveBanner_Handler,
+		},
+		{
+			MethodName: "SetBannerSchedule",
+			Handler:    _Rotation_SetBannerSchedule_Handler,
+		},
+		{
+			MethodName: "SetBannerDayparting",
+			Handler:    _Rotation_SetBannerDayparting_Handler,
+		},
+		{
+			MethodName: "SetBannerShare",
+			Handler:    _Rotation_SetBannerShare_Handler,
+		},
+		{
+			MethodName: "SetBannerBudget",
+			Handler:    _Rotation_SetBannerBudget_Handler,
+		},
+		{
+			MethodName: "SetBannerTargeting",
+			Handler:    _Rotation_SetBannerTargeting_Handler,
+		},
+		{
+			MethodName: "SetSlotStrategy",
+			Handler:    _Rotation_SetSlotStrategy_Handler,
+		},
+		{
+			MethodName: "SetSlotReward",
+			Handler:    _Rotation_SetSlotReward_Handler,
+		},
+		{
+			MethodName: "AddSlot",
+			Handler:    _Rotation_AddSlot_Handler,
+		},
+		{
+			MethodName: "UpdateSlot",
+			Handler:    _Rotation_UpdateSlot_Handler,
+		},
+		{
+			MethodName: "GetSlot",
+			Handler:    _Rotation_GetSlot_Handler,
+		},
+		{
+			MethodName: "ListSlots",
+			Handler:    _Rotation_ListSlots_Handler,
+		},
+		{
+			MethodName: "RemoveSlot",
+			Handler:    _Rotation_RemoveSlot_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/api.proto",
@@ -3,6 +3,9 @@ package grpc
 import (
 	"context"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/koind/banner-rotation/api/internal/domain/repository"
 	"github.com/koind/banner-rotation/api/internal/domain/service"
 	"github.com/koind/banner-rotation/api/internal/rabbit"
@@ -12,6 +15,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"net"
+	"time"
 )
 
 // GRPC rotation service
@@ -20,6 +24,7 @@ type GrpcServer struct {
 	rotationService service.RotationService
 	publisher       rabbit.PublisherInterface
 	logger          *zap.Logger
+	server          *grpc.Server
 }
 
 // NewGRPCServer returns grpc server that wraps rotation business logic
@@ -29,12 +34,18 @@ func NewGRPCServer(
 	publisher rabbit.PublisherInterface,
 	logger *zap.Logger,
 ) *GrpcServer {
-	return &GrpcServer{
+	s := &GrpcServer{
 		domain:          domain,
 		rotationService: rotationService,
 		publisher:       publisher,
 		logger:          logger,
+		server:          grpc.NewServer(),
 	}
+
+	reflection.Register(s.server)
+	pb.RegisterRotationServer(s.server, s)
+
+	return s
 }
 
 // Adds a banner in the rotation
@@ -43,10 +54,36 @@ func (s *GrpcServer) AddBanner(ctx context.Context, req *pb.RotationRequest) (*p
 		return nil, errors.New("client cancelled, abandoning.")
 	}
 
+	startsAt, err := timeOf(req.GetStartsAt())
+	if err != nil {
+		return nil, err
+	}
+
+	endsAt, err := timeOf(req.GetEndsAt())
+	if err != nil {
+		return nil, err
+	}
+
 	rotation := repository.Rotation{
-		BannerID:    int(req.GetBannerId()),
-		SlotID:      int(req.GetSlotId()),
-		Description: req.GetDescription(),
+		BannerID:       int(req.GetBannerId()),
+		SlotID:         int(req.GetSlotId()),
+		Description:    req.GetDescription(),
+		Prior:          req.GetPrior(),
+		PriorViews:     req.GetPriorViews(),
+		PriorClicks:    req.GetPriorClicks(),
+		StartsAt:       startsAt,
+		EndsAt:         endsAt,
+		Timezone:       req.GetTimezone(),
+		Dayparting:     daypartingOf(req.GetDayparting()),
+		Weight:         req.GetWeight(),
+		MinShare:       req.GetMinShare(),
+		MaxShare:       req.GetMaxShare(),
+		ViewsCap:       int(req.GetViewsCap()),
+		ClicksCap:      int(req.GetClicksCap()),
+		DailyViewsCap:  int(req.GetDailyViewsCap()),
+		DailyClicksCap: int(req.GetDailyClicksCap()),
+		Pacing:         req.GetPacing(),
+		Targeting:      targetingOf(req.GetTargeting()),
 	}
 
 	rotation.SetDatetimeOfCreate()
@@ -56,37 +93,64 @@ func (s *GrpcServer) AddBanner(ctx context.Context, req *pb.RotationRequest) (*p
 		return nil, err
 	}
 
-	createdAt, err := ptypes.TimestampProto(newRotation.CreatedAt)
+	return rotationResponse(newRotation)
+}
+
+// Sets the transition on the banner
+func (s *GrpcServer) SetTransition(ctx context.Context, t *pb.Transition) (*pb.Status, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	bannerID := int(t.GetBannerId())
+	groupID := int(t.GetGroupId())
+	attributes := repository.Attributes(t.GetAttributes())
+
+	rotation, err := s.rotationService.RotationRepository.FindOneByBannerID(ctx, bannerID)
 	if err != nil {
 		return nil, err
 	}
 
-	rotationResp := &pb.RotationResponse{
-		Id:          int32(newRotation.ID),
-		BannerId:    int32(newRotation.BannerID),
-		SlotId:      int32(newRotation.SlotID),
-		Description: newRotation.Description,
-		CreateAt:    createdAt,
+	if rotation == nil || rotation.ID == 0 {
+		return nil, service.ErrRotationNotFound
 	}
 
-	return rotationResp, nil
+	statistics, err := s.rotationService.SetTransition(ctx, *rotation, groupID, attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.publisher.Publish(ctx, *statistics)
+	if err != nil {
+		s.logger.Error(
+			"Failed to send message to queue",
+			zap.Error(err),
+		)
+	}
+
+	return &pb.Status{Status: "ok"}, nil
 }
 
-// Sets the transition on the banner
-func (s *GrpcServer) SetTransition(ctx context.Context, t *pb.Transition) (*pb.Status, error) {
+// Records the conversion of the banner with its value
+func (s *GrpcServer) SetConversion(ctx context.Context, c *pb.Conversion) (*pb.Status, error) {
 	if ctx.Err() == context.Canceled {
 		return nil, errors.New("client cancelled, abandoning.")
 	}
 
-	bannerID := int(t.GetBannerId())
-	groupID := int(t.GetGroupId())
+	bannerID := int(c.GetBannerId())
+	groupID := int(c.GetGroupId())
+	attributes := repository.Attributes(c.GetAttributes())
 
 	rotation, err := s.rotationService.RotationRepository.FindOneByBannerID(ctx, bannerID)
 	if err != nil {
 		return nil, err
 	}
 
-	statistics, err := s.rotationService.SetTransition(ctx, *rotation, groupID)
+	if rotation == nil || rotation.ID == 0 {
+		return nil, service.ErrRotationNotFound
+	}
+
+	statistics, err := s.rotationService.SetConversion(ctx, *rotation, groupID, c.GetValue(), attributes)
 	if err != nil {
 		return nil, err
 	}
@@ -110,8 +174,10 @@ func (s *GrpcServer) SelectBanner(ctx context.Context, sl *pb.Select) (*pb.Banne
 
 	slotID := int(sl.GetSlotId())
 	groupID := int(sl.GetGroupId())
+	visitorID := sl.GetVisitorId()
+	attributes := repository.Attributes(sl.GetAttributes())
 
-	bannerID, statistics, err := s.rotationService.SelectBanner(ctx, slotID, groupID)
+	bannerID, statistics, err := s.rotationService.SelectBanner(ctx, slotID, groupID, visitorID, attributes)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +197,48 @@ func (s *GrpcServer) SelectBanner(ctx context.Context, sl *pb.Select) (*pb.Banne
 	return banner, nil
 }
 
+// Selects count distinct banners to display ranked by the strategy
+func (s *GrpcServer) SelectBanners(ctx context.Context, sl *pb.Select) (*pb.Banners, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	slotID := int(sl.GetSlotId())
+	groupID := int(sl.GetGroupId())
+	visitorID := sl.GetVisitorId()
+	count := int(sl.GetCount())
+	attributes := repository.Attributes(sl.GetAttributes())
+
+	bannerIDs, statisticsList, err := s.rotationService.SelectBanners(
+		ctx,
+		slotID,
+		groupID,
+		visitorID,
+		count,
+		attributes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statistics := range statisticsList {
+		err = s.publisher.Publish(ctx, *statistics)
+		if err != nil {
+			s.logger.Error(
+				"Failed to send message to queue",
+				zap.Error(err),
+			)
+		}
+	}
+
+	banners := &pb.Banners{}
+	for _, bannerID := range bannerIDs {
+		banners.Banners = append(banners.Banners, &pb.Banner{Id: int32(bannerID)})
+	}
+
+	return banners, nil
+}
+
 // Removes the banner from the rotation
 func (s *GrpcServer) RemoveBanner(ctx context.Context, banner *pb.Banner) (*pb.Status, error) {
 	if ctx.Err() == context.Canceled {
@@ -147,17 +255,479 @@ func (s *GrpcServer) RemoveBanner(ctx context.Context, banner *pb.Banner) (*pb.S
 	return &pb.Status{Status: "ok"}, nil
 }
 
+// Sets the strategy that selects banners in the slot
+func (s *GrpcServer) SetSlotStrategy(ctx context.Context, req *pb.SlotStrategy) (*pb.SlotStrategy, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	slot, err := s.rotationService.SetStrategy(
+		ctx,
+		int(req.GetSlotId()),
+		req.GetStrategy(),
+		repository.Parameters(req.GetParameters()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SlotStrategy{
+		SlotId:     int32(slot.ID),
+		Strategy:   slot.Strategy,
+		Parameters: slot.Parameters,
+	}, nil
+}
+
+// Sets what the strategy of the slot maximizes
+func (s *GrpcServer) SetSlotReward(ctx context.Context, req *pb.SlotReward) (*pb.SlotReward, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	slot, err := s.rotationService.SetReward(ctx, int(req.GetSlotId()), req.GetReward())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SlotReward{
+		SlotId: int32(slot.ID),
+		Reward: slot.Reward,
+	}, nil
+}
+
+// Adds a new slot with its settings
+func (s *GrpcServer) AddSlot(ctx context.Context, req *pb.Slot) (*pb.Slot, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	slot, err := s.rotationService.AddSlot(ctx, slotOf(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return slotResponse(slot), nil
+}
+
+// Replaces the settings of the slot
+func (s *GrpcServer) UpdateSlot(ctx context.Context, req *pb.Slot) (*pb.Slot, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	slot, err := s.rotationService.UpdateSlot(ctx, slotOf(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return slotResponse(slot), nil
+}
+
+// Returns the slot with its settings
+func (s *GrpcServer) GetSlot(ctx context.Context, req *pb.SlotId) (*pb.Slot, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	slot, err := s.rotationService.FindSlot(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return slotResponse(slot), nil
+}
+
+// Returns all the slots with settings
+func (s *GrpcServer) ListSlots(ctx context.Context, req *empty.Empty) (*pb.Slots, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	slots, err := s.rotationService.FindAllSlots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.Slots{}
+	for _, slot := range slots {
+		response.Slots = append(response.Slots, slotResponse(slot))
+	}
+
+	return response, nil
+}
+
+// Removes the settings of the slot
+func (s *GrpcServer) RemoveSlot(ctx context.Context, req *pb.SlotId) (*pb.Status, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	err := s.rotationService.RemoveSlot(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Status{Status: "ok"}, nil
+}
+
+// Pauses the banner, its statistics are kept
+func (s *GrpcServer) PauseBanner(ctx context.Context, banner *pb.Banner) (*pb.RotationResponse, error) {
+	return s.setStatus(ctx, banner, repository.RotationStatusPaused)
+}
+
+// Resumes the paused banner
+func (s *GrpcServer) ResumeBanner(ctx context.Context, banner *pb.Banner) (*pb.RotationResponse, error) {
+	return s.setStatus(ctx, banner, repository.RotationStatusActive)
+}
+
+// Archives the banner, its statistics are kept
+func (s *GrpcServer) ArchiveBanner(ctx context.Context, banner *pb.Banner) (*pb.RotationResponse, error) {
+	return s.setStatus(ctx, banner, repository.RotationStatusArchived)
+}
+
+// Sets the status of the banner
+func (s *GrpcServer) setStatus(ctx context.Context, banner *pb.Banner, status string) (*pb.RotationResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	rotation, err := s.rotationService.SetStatus(ctx, int(banner.GetId()), status)
+	if err != nil {
+		return nil, err
+	}
+
+	return rotationResponse(rotation)
+}
+
+// Sets when the banner joins and leaves the slot
+func (s *GrpcServer) SetBannerSchedule(ctx context.Context, req *pb.Schedule) (*pb.RotationResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	startsAt, err := timeOf(req.GetStartsAt())
+	if err != nil {
+		return nil, err
+	}
+
+	endsAt, err := timeOf(req.GetEndsAt())
+	if err != nil {
+		return nil, err
+	}
+
+	rotation, err := s.rotationService.SetSchedule(ctx, int(req.GetBannerId()), startsAt, endsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return rotationResponse(rotation)
+}
+
+// Sets the hours and the weekdays the banner is selected at
+func (s *GrpcServer) SetBannerDayparting(ctx context.Context, req *pb.Dayparting) (*pb.RotationResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	rotation, err := s.rotationService.SetDayparting(
+		ctx,
+		int(req.GetBannerId()),
+		req.GetTimezone(),
+		daypartingOf(req.GetRules()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rotationResponse(rotation)
+}
+
+// Sets the weight of the banner and the bounds of its share of the views of the slot
+func (s *GrpcServer) SetBannerShare(ctx context.Context, req *pb.Share) (*pb.RotationResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	rotation, err := s.rotationService.SetShare(
+		ctx,
+		int(req.GetBannerId()),
+		req.GetWeight(),
+		req.GetMinShare(),
+		req.GetMaxShare(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rotationResponse(rotation)
+}
+
+// Sets the total and daily caps of the views and clicks of the banner
+func (s *GrpcServer) SetBannerBudget(ctx context.Context, req *pb.Budget) (*pb.RotationResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	rotation, err := s.rotationService.SetBudget(
+		ctx,
+		int(req.GetBannerId()),
+		int(req.GetViewsCap()),
+		int(req.GetClicksCap()),
+		int(req.GetDailyViewsCap()),
+		int(req.GetDailyClicksCap()),
+		req.GetPacing(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rotationResponse(rotation)
+}
+
+// Sets the groups and the attributes of the requests the banner is selected for
+func (s *GrpcServer) SetBannerTargeting(ctx context.Context, req *pb.Targeting) (*pb.RotationResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, errors.New("client cancelled, abandoning.")
+	}
+
+	rotation, err := s.rotationService.SetTargeting(ctx, int(req.GetBannerId()), targetingOf(req.GetRules()))
+	if err != nil {
+		return nil, err
+	}
+
+	return rotationResponse(rotation)
+}
+
+// Returns the response with the rotation
+func rotationResponse(rotation *repository.Rotation) (*pb.RotationResponse, error) {
+	createdAt, err := ptypes.TimestampProto(rotation.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	startsAt, err := timestampOf(rotation.StartsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	endsAt, err := timestampOf(rotation.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RotationResponse{
+		Id:             int32(rotation.ID),
+		BannerId:       int32(rotation.BannerID),
+		SlotId:         int32(rotation.SlotID),
+		Description:    rotation.Description,
+		Status:         rotation.Status,
+		CreateAt:       createdAt,
+		Prior:          rotation.Prior,
+		PriorViews:     rotation.PriorViews,
+		PriorClicks:    rotation.PriorClicks,
+		StartsAt:       startsAt,
+		EndsAt:         endsAt,
+		Timezone:       rotation.Timezone,
+		Dayparting:     daypartRules(rotation.Dayparting),
+		Weight:         rotation.Weight,
+		MinShare:       rotation.MinShare,
+		MaxShare:       rotation.MaxShare,
+		ViewsCap:       int32(rotation.ViewsCap),
+		ClicksCap:      int32(rotation.ClicksCap),
+		DailyViewsCap:  int32(rotation.DailyViewsCap),
+		DailyClicksCap: int32(rotation.DailyClicksCap),
+		Pacing:         rotation.Pacing,
+		Targeting:      targetingRules(rotation.Targeting),
+	}, nil
+}
+
+// Returns the dayparting of the rules of the request
+func daypartingOf(rules []*pb.DaypartRule) repository.Dayparting {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	dayparting := make(repository.Dayparting, 0, len(rules))
+
+	for _, rule := range rules {
+		weekdays := make([]time.Weekday, 0, len(rule.GetWeekdays()))
+		for _, weekday := range rule.GetWeekdays() {
+			weekdays = append(weekdays, time.Weekday(weekday))
+		}
+
+		dayparting = append(dayparting, repository.DaypartRule{
+			Weekdays: weekdays,
+			From:     int(rule.GetFrom()),
+			To:       int(rule.GetTo()),
+		})
+	}
+
+	return dayparting
+}
+
+// Returns the rules of the dayparting for the response
+func daypartRules(dayparting repository.Dayparting) []*pb.DaypartRule {
+	rules := make([]*pb.DaypartRule, 0, len(dayparting))
+
+	for _, rule := range dayparting {
+		weekdays := make([]int32, 0, len(rule.Weekdays))
+		for _, weekday := range rule.Weekdays {
+			weekdays = append(weekdays, int32(weekday))
+		}
+
+		rules = append(rules, &pb.DaypartRule{
+			Weekdays: weekdays,
+			From:     int32(rule.From),
+			To:       int32(rule.To),
+		})
+	}
+
+	return rules
+}
+
+// Returns the slot of the request, the slot without the enabled flag keeps its flag or is enabled when it is new
+func slotOf(req *pb.Slot) repository.Slot {
+	slot := repository.Slot{
+		ID:               int(req.GetId()),
+		Name:             req.GetName(),
+		Description:      req.GetDescription(),
+		Width:            int(req.GetWidth()),
+		Height:           int(req.GetHeight()),
+		Strategy:         req.GetStrategy(),
+		Parameters:       repository.Parameters(req.GetParameters()),
+		Reward:           req.GetReward(),
+		FallbackBannerID: int(req.GetFallbackBannerId()),
+	}
+
+	if req.GetEnabled() != nil {
+		enabled := req.GetEnabled().GetValue()
+		slot.Enabled = &enabled
+	}
+
+	return slot
+}
+
+// Returns the slot for the response
+func slotResponse(slot *repository.Slot) *pb.Slot {
+	return &pb.Slot{
+		Id:               int32(slot.ID),
+		Name:             slot.Name,
+		Description:      slot.Description,
+		Width:            int32(slot.Width),
+		Height:           int32(slot.Height),
+		Strategy:         slot.Strategy,
+		Parameters:       slot.Parameters,
+		Reward:           slot.Reward,
+		FallbackBannerId: int32(slot.FallbackBannerID),
+		Enabled:          &wrappers.BoolValue{Value: slot.IsEnabled()},
+	}
+}
+
+// Returns the targeting of the rules of the request, nil when the rules are not set
+func targetingOf(rules *pb.TargetingRules) *repository.Targeting {
+	if rules == nil {
+		return nil
+	}
+
+	groups := make([]int, 0, len(rules.GetGroups()))
+	for _, group := range rules.GetGroups() {
+		groups = append(groups, int(group))
+	}
+
+	deniedGroups := make([]int, 0, len(rules.GetDeniedGroups()))
+	for _, group := range rules.GetDeniedGroups() {
+		deniedGroups = append(deniedGroups, int(group))
+	}
+
+	return &repository.Targeting{
+		Groups:          groups,
+		DeniedGroups:    deniedGroups,
+		Countries:       rules.GetCountries(),
+		DeniedCountries: rules.GetDeniedCountries(),
+		Devices:         rules.GetDevices(),
+		DeniedDevices:   rules.GetDeniedDevices(),
+		Languages:       rules.GetLanguages(),
+		DeniedLanguages: rules.GetDeniedLanguages(),
+	}
+}
+
+// Returns the rules of the targeting for the response, nil when the banner is not targeted
+func targetingRules(targeting *repository.Targeting) *pb.TargetingRules {
+	if targeting == nil {
+		return nil
+	}
+
+	groups := make([]int32, 0, len(targeting.Groups))
+	for _, group := range targeting.Groups {
+		groups = append(groups, int32(group))
+	}
+
+	deniedGroups := make([]int32, 0, len(targeting.DeniedGroups))
+	for _, group := range targeting.DeniedGroups {
+		deniedGroups = append(deniedGroups, int32(group))
+	}
+
+	return &pb.TargetingRules{
+		Groups:          groups,
+		DeniedGroups:    deniedGroups,
+		Countries:       targeting.Countries,
+		DeniedCountries: targeting.DeniedCountries,
+		Devices:         targeting.Devices,
+		DeniedDevices:   targeting.DeniedDevices,
+		Languages:       targeting.Languages,
+		DeniedLanguages: targeting.DeniedLanguages,
+	}
+}
+
+// Returns the time of the timestamp, nil when the timestamp is not set
+func timeOf(ts *timestamp.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// Returns the timestamp of the time, nil when the time is not set
+func timestampOf(t *time.Time) (*timestamp.Timestamp, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	return ptypes.TimestampProto(*t)
+}
+
 // Start fires up the grpc server
 func (s *GrpcServer) Start() error {
-	gs := grpc.NewServer()
-	reflection.Register(gs)
-
 	l, err := net.Listen("tcp", s.domain)
 	if err != nil {
 		return err
 	}
 
-	pb.RegisterRotationServer(gs, s)
+	return s.server.Serve(l)
+}
+
+// Shutdown stops accepting the connections and waits for the active calls to finish,
+// the calls are canceled when the context is done
+func (s *GrpcServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
 
-	return gs.Serve(l)
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+
+		return ctx.Err()
+	}
 }
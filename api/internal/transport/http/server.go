@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"github.com/gorilla/mux"
 	"net/http"
 )
@@ -9,24 +10,51 @@ import (
 type HttpServer struct {
 	domain string
 	router http.Handler
+	server *http.Server
 	s      *RotationService
 }
 
-// Start fires up the http server
+// Start fires up the http server, returns http.ErrServerClosed after the shutdown
 func (s *HttpServer) Start() error {
-	return http.ListenAndServe(s.domain, s.router)
+	return s.server.ListenAndServe()
+}
+
+// Shutdown stops accepting the connections and waits for the active requests to finish
+func (s *HttpServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
 }
 
 // NewHTTPServer returns http server that wraps rotation business logic
 func NewHTTPServer(handleService *RotationService, domain string) *HttpServer {
 
 	r := mux.NewRouter()
-	hs := HttpServer{router: r, domain: domain, s: handleService}
+	hs := HttpServer{
+		router: r,
+		domain: domain,
+		server: &http.Server{Addr: domain, Handler: r},
+		s:      handleService,
+	}
 
 	r.HandleFunc("/banner/add", handleService.AddBannerHandle).Methods("POST")
 	r.HandleFunc("/banner/set-transition", handleService.SetTransitionHandle).Methods("POST")
+	r.HandleFunc("/banner/set-conversion", handleService.SetConversionHandle).Methods("POST")
 	r.HandleFunc("/banner/select", handleService.SelectBannerHandle).Methods("POST")
 	r.HandleFunc("/banner/remove/{id}", handleService.RemoveBannerHandle).Methods("DELETE")
+	r.HandleFunc("/banner/pause/{id}", handleService.PauseBannerHandle).Methods("POST")
+	r.HandleFunc("/banner/resume/{id}", handleService.ResumeBannerHandle).Methods("POST")
+	r.HandleFunc("/banner/archive/{id}", handleService.ArchiveBannerHandle).Methods("POST")
+	r.HandleFunc("/banner/schedule", handleService.SetScheduleHandle).Methods("POST")
+	r.HandleFunc("/banner/dayparting", handleService.SetDaypartingHandle).Methods("POST")
+	r.HandleFunc("/banner/share", handleService.SetShareHandle).Methods("POST")
+	r.HandleFunc("/banner/budget", handleService.SetBudgetHandle).Methods("POST")
+	r.HandleFunc("/banner/targeting", handleService.SetTargetingHandle).Methods("POST")
+	r.HandleFunc("/slot/strategy", handleService.SetStrategyHandle).Methods("POST")
+	r.HandleFunc("/slot/reward", handleService.SetRewardHandle).Methods("POST")
+	r.HandleFunc("/slot/add", handleService.AddSlotHandle).Methods("POST")
+	r.HandleFunc("/slot/update", handleService.UpdateSlotHandle).Methods("POST")
+	r.HandleFunc("/slot/list", handleService.ListSlotsHandle).Methods("GET")
+	r.HandleFunc("/slot/{id:[0-9]+}", handleService.GetSlotHandle).Methods("GET")
+	r.HandleFunc("/slot/remove/{id}", handleService.RemoveSlotHandle).Methods("DELETE")
 
 	http.Handle("/", r)
 
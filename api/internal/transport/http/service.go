@@ -1,14 +1,17 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/gorilla/mux"
 	"github.com/koind/banner-rotation/api/internal/domain/repository"
 	"github.com/koind/banner-rotation/api/internal/domain/service"
 	"github.com/koind/banner-rotation/api/internal/rabbit"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 // HTTP rotation service
@@ -68,8 +71,9 @@ func (s *RotationService) SetTransitionHandle(w http.ResponseWriter, r *http.Req
 	decoder := json.NewDecoder(r.Body)
 
 	var rotationForm struct {
-		BannerID int `json:"bannerId"`
-		GroupID  int `json:"groupId"`
+		BannerID   int                   `json:"bannerId"`
+		GroupID    int                   `json:"groupId"`
+		Attributes repository.Attributes `json:"attributes"`
 	}
 
 	err := decoder.Decode(&rotationForm)
@@ -88,24 +92,105 @@ func (s *RotationService) SetTransitionHandle(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	statistics, err := s.SetTransition(r.Context(), *rotation, rotationForm.GroupID)
+	if rotation == nil || rotation.ID == 0 {
+		w.WriteHeader(404)
+		w.Write([]byte(service.ErrRotationNotFound.Error()))
+
+		return
+	}
+
+	statistics, err := s.SetTransition(r.Context(), *rotation, rotationForm.GroupID, rotationForm.Attributes)
 	if err != nil {
 		s.logger.Error(
 			"Error when set the transition on the banner",
 			zap.Error(err),
 		)
 
+		w.WriteHeader(400)
 		w.Write([]byte(err.Error()))
-	} else {
-		s.logger.Info(
-			"Was set the transition on the banner",
-			zap.Any("bannerID", rotationForm.BannerID),
-			zap.Any("groupID", rotationForm.GroupID),
+
+		return
+	}
+
+	s.logger.Info(
+		"Was set the transition on the banner",
+		zap.Any("bannerID", rotationForm.BannerID),
+		zap.Any("groupID", rotationForm.GroupID),
+	)
+
+	w.Write([]byte("ok"))
+
+	err = s.publisher.Publish(r.Context(), *statistics)
+	if err != nil {
+		s.logger.Error(
+			"Failed to send message to queue",
+			zap.Error(err),
 		)
+	}
+}
 
-		w.Write([]byte("ok"))
+// Records the conversion of the banner with its value
+func (s *RotationService) SetConversionHandle(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+
+	var conversionForm struct {
+		BannerID   int                   `json:"bannerId"`
+		GroupID    int                   `json:"groupId"`
+		Value      float64               `json:"value"`
+		Attributes repository.Attributes `json:"attributes"`
+	}
+
+	err := decoder.Decode(&conversionForm)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	rotation, err := s.RotationRepository.FindOneByBannerID(r.Context(), conversionForm.BannerID)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	if rotation == nil || rotation.ID == 0 {
+		w.WriteHeader(404)
+		w.Write([]byte(service.ErrRotationNotFound.Error()))
+
+		return
+	}
+
+	statistics, err := s.SetConversion(
+		r.Context(),
+		*rotation,
+		conversionForm.GroupID,
+		conversionForm.Value,
+		conversionForm.Attributes,
+	)
+	if err != nil {
+		s.logger.Error(
+			"Error when set the conversion on the banner",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
 	}
 
+	s.logger.Info(
+		"Was set the conversion on the banner",
+		zap.Any("bannerID", conversionForm.BannerID),
+		zap.Any("groupID", conversionForm.GroupID),
+		zap.Any("value", conversionForm.Value),
+	)
+
+	w.Write([]byte("ok"))
+
 	err = s.publisher.Publish(r.Context(), *statistics)
 	if err != nil {
 		s.logger.Error(
@@ -115,13 +200,16 @@ func (s *RotationService) SetTransitionHandle(w http.ResponseWriter, r *http.Req
 	}
 }
 
-// Selects a banner to display
+// Selects a banner to display, selects the list of count distinct banners when the count is set
 func (s *RotationService) SelectBannerHandle(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 
 	var rotationForm struct {
-		SlotID  int `json:"slotId"`
-		GroupID int `json:"groupId"`
+		SlotID     int                   `json:"slotId"`
+		GroupID    int                   `json:"groupId"`
+		VisitorID  string                `json:"visitorId"`
+		Count      int                   `json:"count"`
+		Attributes repository.Attributes `json:"attributes"`
 	}
 
 	err := decoder.Decode(&rotationForm)
@@ -132,25 +220,77 @@ func (s *RotationService) SelectBannerHandle(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	bannerID, statistics, err := s.SelectBanner(r.Context(), rotationForm.SlotID, rotationForm.GroupID)
+	if rotationForm.Count != 0 {
+		bannerIDs, statisticsList, err := s.SelectBanners(
+			r.Context(),
+			rotationForm.SlotID,
+			rotationForm.GroupID,
+			rotationForm.VisitorID,
+			rotationForm.Count,
+			rotationForm.Attributes,
+		)
+		if err != nil {
+			s.logger.Error(
+				"Error when select banners",
+				zap.Error(err),
+			)
+
+			w.WriteHeader(selectionStatus(err))
+			w.Write([]byte(err.Error()))
+
+			return
+		}
+
+		s.logger.Info(
+			"Were selected the banners to view",
+			zap.Any("slotID", rotationForm.SlotID),
+			zap.Any("groupID", rotationForm.GroupID),
+			zap.Any("bannerIDs", bannerIDs),
+		)
+
+		json.NewEncoder(w).Encode(bannerIDs)
+
+		for _, statistics := range statisticsList {
+			err = s.publisher.Publish(r.Context(), *statistics)
+			if err != nil {
+				s.logger.Error(
+					"Failed to send message to queue",
+					zap.Error(err),
+				)
+			}
+		}
+
+		return
+	}
+
+	bannerID, statistics, err := s.SelectBanner(
+		r.Context(),
+		rotationForm.SlotID,
+		rotationForm.GroupID,
+		rotationForm.VisitorID,
+		rotationForm.Attributes,
+	)
 	if err != nil {
 		s.logger.Error(
 			"Error when select banner",
 			zap.Error(err),
 		)
 
+		w.WriteHeader(selectionStatus(err))
 		w.Write([]byte(err.Error()))
-	} else {
-		s.logger.Info(
-			"Was selected the banner to view",
-			zap.Any("slotID", rotationForm.SlotID),
-			zap.Any("groupID", rotationForm.GroupID),
-			zap.Any("bannerID", bannerID),
-		)
 
-		json.NewEncoder(w).Encode(bannerID)
+		return
 	}
 
+	s.logger.Info(
+		"Was selected the banner to view",
+		zap.Any("slotID", rotationForm.SlotID),
+		zap.Any("groupID", rotationForm.GroupID),
+		zap.Any("bannerID", bannerID),
+	)
+
+	json.NewEncoder(w).Encode(bannerID)
+
 	err = s.publisher.Publish(r.Context(), *statistics)
 	if err != nil {
 		s.logger.Error(
@@ -160,6 +300,16 @@ func (s *RotationService) SelectBannerHandle(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// Returns the status of the failed selection, not found when the slot has no banners to select
+func selectionStatus(err error) int {
+	switch errors.Cause(err) {
+	case service.ErrRotationsListEmpty, service.ErrSlotDisabled, service.ErrSlotNotFound:
+		return 404
+	}
+
+	return 400
+}
+
 // Removes the banner from the rotation
 func (s *RotationService) RemoveBannerHandle(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -194,3 +344,453 @@ func (s *RotationService) RemoveBannerHandle(w http.ResponseWriter, r *http.Requ
 		w.Write([]byte("Banner id not found"))
 	}
 }
+
+// Pauses the banner, its statistics are kept
+func (s *RotationService) PauseBannerHandle(w http.ResponseWriter, r *http.Request) {
+	s.setStatus(w, r, repository.RotationStatusPaused)
+}
+
+// Resumes the paused banner
+func (s *RotationService) ResumeBannerHandle(w http.ResponseWriter, r *http.Request) {
+	s.setStatus(w, r, repository.RotationStatusActive)
+}
+
+// Archives the banner, its statistics are kept
+func (s *RotationService) ArchiveBannerHandle(w http.ResponseWriter, r *http.Request) {
+	s.setStatus(w, r, repository.RotationStatusArchived)
+}
+
+// Sets the status of the banner with the id of the path
+func (s *RotationService) setStatus(w http.ResponseWriter, r *http.Request, status string) {
+	vars := mux.Vars(r)
+
+	id, ok := vars["id"]
+	if !ok {
+		w.WriteHeader(400)
+		w.Write([]byte("Banner id not found"))
+
+		return
+	}
+
+	bannerID, err := strconv.Atoi(id)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	rotation, err := s.SetStatus(r.Context(), bannerID, status)
+	if err != nil {
+		s.logger.Error(
+			"Error when set the banner status",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+	} else {
+		s.logger.Info(
+			"Was set the banner status",
+			zap.Any("rotation", rotation),
+		)
+
+		json.NewEncoder(w).Encode(rotation)
+	}
+}
+
+// Sets when the banner joins and leaves the slot
+func (s *RotationService) SetScheduleHandle(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+
+	var scheduleForm struct {
+		BannerID int        `json:"bannerId"`
+		StartsAt *time.Time `json:"startsAt"`
+		EndsAt   *time.Time `json:"endsAt"`
+	}
+
+	err := decoder.Decode(&scheduleForm)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	rotation, err := s.SetSchedule(r.Context(), scheduleForm.BannerID, scheduleForm.StartsAt, scheduleForm.EndsAt)
+	if err != nil {
+		s.logger.Error(
+			"Error when set the banner schedule",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+	} else {
+		s.logger.Info(
+			"Was set the banner schedule",
+			zap.Any("rotation", rotation),
+		)
+
+		json.NewEncoder(w).Encode(rotation)
+	}
+}
+
+// Sets the hours and the weekdays the banner is selected at
+func (s *RotationService) SetDaypartingHandle(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+
+	var daypartingForm struct {
+		BannerID   int                   `json:"bannerId"`
+		Timezone   string                `json:"timezone"`
+		Dayparting repository.Dayparting `json:"dayparting"`
+	}
+
+	err := decoder.Decode(&daypartingForm)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	rotation, err := s.SetDayparting(
+		r.Context(),
+		daypartingForm.BannerID,
+		daypartingForm.Timezone,
+		daypartingForm.Dayparting,
+	)
+	if err != nil {
+		s.logger.Error(
+			"Error when set the banner dayparting",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+	} else {
+		s.logger.Info(
+			"Was set the banner dayparting",
+			zap.Any("rotation", rotation),
+		)
+
+		json.NewEncoder(w).Encode(rotation)
+	}
+}
+
+// Sets the weight of the banner and the bounds of its share of the views of the slot
+func (s *RotationService) SetShareHandle(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+
+	var shareForm struct {
+		BannerID int     `json:"bannerId"`
+		Weight   float64 `json:"weight"`
+		MinShare float64 `json:"minShare"`
+		MaxShare float64 `json:"maxShare"`
+	}
+
+	err := decoder.Decode(&shareForm)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	rotation, err := s.SetShare(
+		r.Context(),
+		shareForm.BannerID,
+		shareForm.Weight,
+		shareForm.MinShare,
+		shareForm.MaxShare,
+	)
+	if err != nil {
+		s.logger.Error(
+			"Error when set the banner share",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+	} else {
+		s.logger.Info(
+			"Was set the banner share",
+			zap.Any("rotation", rotation),
+		)
+
+		json.NewEncoder(w).Encode(rotation)
+	}
+}
+
+// Sets the total and daily caps of the views and clicks of the banner
+func (s *RotationService) SetBudgetHandle(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+
+	var budgetForm struct {
+		BannerID       int  `json:"bannerId"`
+		ViewsCap       int  `json:"viewsCap"`
+		ClicksCap      int  `json:"clicksCap"`
+		DailyViewsCap  int  `json:"dailyViewsCap"`
+		DailyClicksCap int  `json:"dailyClicksCap"`
+		Pacing         bool `json:"pacing"`
+	}
+
+	err := decoder.Decode(&budgetForm)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	rotation, err := s.SetBudget(
+		r.Context(),
+		budgetForm.BannerID,
+		budgetForm.ViewsCap,
+		budgetForm.ClicksCap,
+		budgetForm.DailyViewsCap,
+		budgetForm.DailyClicksCap,
+		budgetForm.Pacing,
+	)
+	if err != nil {
+		s.logger.Error(
+			"Error when set the banner budget",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+	} else {
+		s.logger.Info(
+			"Was set the banner budget",
+			zap.Any("rotation", rotation),
+		)
+
+		json.NewEncoder(w).Encode(rotation)
+	}
+}
+
+// Sets the groups and the attributes of the requests the banner is selected for
+func (s *RotationService) SetTargetingHandle(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+
+	var targetingForm struct {
+		BannerID  int                   `json:"bannerId"`
+		Targeting *repository.Targeting `json:"targeting"`
+	}
+
+	err := decoder.Decode(&targetingForm)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	rotation, err := s.SetTargeting(r.Context(), targetingForm.BannerID, targetingForm.Targeting)
+	if err != nil {
+		s.logger.Error(
+			"Error when set the banner targeting",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+	} else {
+		s.logger.Info(
+			"Was set the banner targeting",
+			zap.Any("rotation", rotation),
+		)
+
+		json.NewEncoder(w).Encode(rotation)
+	}
+}
+
+// Sets the strategy that selects banners in the slot
+func (s *RotationService) SetStrategyHandle(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+
+	var slotForm struct {
+		SlotID     int                   `json:"slotId"`
+		Strategy   string                `json:"strategy"`
+		Parameters repository.Parameters `json:"parameters"`
+	}
+
+	err := decoder.Decode(&slotForm)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	slot, err := s.SetStrategy(r.Context(), slotForm.SlotID, slotForm.Strategy, slotForm.Parameters)
+	if err != nil {
+		s.logger.Error(
+			"Error when set the slot strategy",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+	} else {
+		s.logger.Info(
+			"Was set the slot strategy",
+			zap.Any("slot", slot),
+		)
+
+		json.NewEncoder(w).Encode(slot)
+	}
+}
+
+// Sets what the strategy of the slot maximizes
+func (s *RotationService) SetRewardHandle(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+
+	var slotForm struct {
+		SlotID int    `json:"slotId"`
+		Reward string `json:"reward"`
+	}
+
+	err := decoder.Decode(&slotForm)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	slot, err := s.SetReward(r.Context(), slotForm.SlotID, slotForm.Reward)
+	if err != nil {
+		s.logger.Error(
+			"Error when set the slot reward",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+	} else {
+		s.logger.Info(
+			"Was set the slot reward",
+			zap.Any("slot", slot),
+		)
+
+		json.NewEncoder(w).Encode(slot)
+	}
+}
+
+// Adds a new slot with its settings
+func (s *RotationService) AddSlotHandle(w http.ResponseWriter, r *http.Request) {
+	s.saveSlot(w, r, s.AddSlot)
+}
+
+// Replaces the settings of the slot
+func (s *RotationService) UpdateSlotHandle(w http.ResponseWriter, r *http.Request) {
+	s.saveSlot(w, r, s.UpdateSlot)
+}
+
+// Decodes the slot and saves it
+func (s *RotationService) saveSlot(
+	w http.ResponseWriter,
+	r *http.Request,
+	save func(ctx context.Context, slot repository.Slot) (*repository.Slot, error),
+) {
+	decoder := json.NewDecoder(r.Body)
+
+	var slot repository.Slot
+
+	err := decoder.Decode(&slot)
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	saved, err := save(r.Context(), slot)
+	if err != nil {
+		s.logger.Error(
+			"Error when save the slot",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+	} else {
+		s.logger.Info(
+			"Was saved the slot",
+			zap.Any("slot", saved),
+		)
+
+		json.NewEncoder(w).Encode(saved)
+	}
+}
+
+// Returns the slot with its settings
+func (s *RotationService) GetSlotHandle(w http.ResponseWriter, r *http.Request) {
+	slotID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	slot, err := s.FindSlot(r.Context(), slotID)
+	if err != nil {
+		s.logger.Error(
+			"Error when find the slot",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(404)
+		w.Write([]byte(err.Error()))
+	} else {
+		json.NewEncoder(w).Encode(slot)
+	}
+}
+
+// Returns all the slots with settings
+func (s *RotationService) ListSlotsHandle(w http.ResponseWriter, r *http.Request) {
+	slots, err := s.FindAllSlots(r.Context())
+	if err != nil {
+		s.logger.Error(
+			"Error when find the slots",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(500)
+		w.Write([]byte(err.Error()))
+	} else {
+		json.NewEncoder(w).Encode(slots)
+	}
+}
+
+// Removes the settings of the slot
+func (s *RotationService) RemoveSlotHandle(w http.ResponseWriter, r *http.Request) {
+	slotID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	err = s.RemoveSlot(r.Context(), slotID)
+	if err != nil {
+		s.logger.Error(
+			"Error removing the slot",
+			zap.Error(err),
+		)
+
+		w.WriteHeader(400)
+		w.Write([]byte(err.Error()))
+	} else {
+		s.logger.Info(
+			"The slot has been removed",
+			zap.Int("slotID", slotID),
+		)
+
+		w.Write([]byte("ok"))
+	}
+}
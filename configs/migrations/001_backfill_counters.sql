@@ -0,0 +1,20 @@
+-- Run once on the database created before the counters: aggregates the recorded statistics
+-- into the hourly counters and the counters into the totals
+begin;
+insert into counters (slot_id, group_id, banner_id, period, position, views, clicks, conversions, value,
+    inverse_probability)
+select slot_id, group_id, banner_id, date_trunc('hour', created_at), position,
+    count(*) filter (where type = 1),
+    count(*) filter (where type = 2),
+    count(*) filter (where type = 3),
+    coalesce(sum(value) filter (where type = 3), 0),
+    count(*) filter (where type = 1)
+from statistics
+group by slot_id, group_id, banner_id, date_trunc('hour', created_at), position;
+insert into counter_totals (slot_id, group_id, banner_id, position, views, clicks, conversions, value,
+    inverse_probability)
+select slot_id, group_id, banner_id, position, sum(views), sum(clicks), sum(conversions), sum(value),
+    sum(inverse_probability)
+from counters
+group by slot_id, group_id, banner_id, position;
+commit;
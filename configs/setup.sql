@@ -3,6 +3,23 @@ create table rotations (
     banner_id bigint not null,
     slot_id bigint not null,
     description text not null,
+    status text not null default 'active',
+    prior text not null default '',
+    prior_views double precision not null default 0,
+    prior_clicks double precision not null default 0,
+    starts_at timestamp null,
+    ends_at timestamp null,
+    timezone text not null default '',
+    dayparting jsonb not null default '[]',
+    weight double precision not null default 0,
+    min_share double precision not null default 0,
+    max_share double precision not null default 0,
+    views_cap bigint not null default 0,
+    clicks_cap bigint not null default 0,
+    daily_views_cap bigint not null default 0,
+    daily_clicks_cap bigint not null default 0,
+    pacing boolean not null default false,
+    targeting jsonb null,
     created_at timestamp not null
 );
 create index slot_idx on rotations (slot_id);
@@ -13,8 +30,66 @@ create table statistics (
     banner_id bigint not null,
     slot_id bigint not null,
     group_id bigint not null,
-    created_at timestamp not null
+    value double precision not null default 0,
+    position bigint not null default 0,
+    strategy text not null default '',
+    attributes jsonb not null default '{}',
+    created_at timestamp not null,
+    probability double precision not null default 0
 );
 create index banner_idx_s on statistics (banner_id);
 create index slot_idx_s on statistics (slot_id);
 create index group_idx_s on statistics (group_id);
+
+create table slots (
+    id bigint primary key,
+    name text not null default '',
+    description text not null default '',
+    width bigint not null default 0,
+    height bigint not null default 0,
+    strategy text not null,
+    parameters jsonb not null default '{}',
+    reward text not null default '',
+    fallback_banner_id bigint not null default 0,
+    enabled boolean not null default true
+);
+
+create table counters (
+    slot_id bigint not null,
+    group_id bigint not null,
+    banner_id bigint not null,
+    period timestamp not null,
+    position bigint not null default 0,
+    views bigint not null default 0,
+    clicks bigint not null default 0,
+    conversions bigint not null default 0,
+    value double precision not null default 0,
+    inverse_probability double precision not null default 0,
+    primary key (slot_id, group_id, banner_id, period, position)
+);
+create index banner_idx_c on counters (banner_id, group_id);
+
+create table counter_totals (
+    slot_id bigint not null,
+    group_id bigint not null,
+    banner_id bigint not null,
+    position bigint not null default 0,
+    views bigint not null default 0,
+    clicks bigint not null default 0,
+    conversions bigint not null default 0,
+    value double precision not null default 0,
+    inverse_probability double precision not null default 0,
+    primary key (slot_id, group_id, banner_id, position)
+);
+create index banner_idx_ct on counter_totals (banner_id, group_id);
+
+create table impressions (
+    id serial primary key,
+    visitor_id text not null,
+    banner_id bigint not null,
+    slot_id bigint not null,
+    created_at timestamp not null,
+    expires_at timestamp not null
+);
+create index visitor_idx on impressions (visitor_id, expires_at);
+create index expires_idx on impressions (expires_at);